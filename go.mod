@@ -6,6 +6,7 @@ require (
 	github.com/PagerDuty/go-pagerduty v1.6.0
 	go.video.xarth.tv/aws-ivs/gsoc/mercy-backend v0.0.0-20221007030314-396c6f1d3580
 	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -32,5 +33,4 @@ require (
 	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.8.0
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
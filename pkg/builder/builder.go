@@ -0,0 +1,434 @@
+// Package builder builds go-pagerduty resources for tests. Every builder starts out with
+// the fields PagerDuty itself would fill in, and chained setters change the fields a test cares about:
+//
+//	incident := builder.Incident("Q1").Title("Disk full").Urgency("low").Service("PSERVICE").Build()
+package builder
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// formatTime renders times the way PagerDuty does, keeping their offset
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// reference returns the reference of type kind to the resource with the given ID
+func reference(kind, id string) pagerduty.APIObject {
+	return pagerduty.APIObject{ID: id, Type: kind + "_reference"}
+}
+
+// UserReference returns a reference to the user with the given ID, e.g. to target by an escalation rule
+func UserReference(id string) pagerduty.APIObject {
+	return reference("user", id)
+}
+
+// ScheduleReference returns a reference to the schedule with the given ID, e.g. to target by an escalation rule
+func ScheduleReference(id string) pagerduty.APIObject {
+	return reference("schedule", id)
+}
+
+// IncidentBuilder builds a pagerduty.Incident, by default a high urgency incident triggered now
+type IncidentBuilder struct {
+	incident pagerduty.Incident
+}
+
+// Incident starts building the incident with the given ID
+func Incident(id string) *IncidentBuilder {
+	title := "Incident " + id
+	return &IncidentBuilder{incident: pagerduty.Incident{
+		APIObject: pagerduty.APIObject{ID: id, Type: "incident", Summary: title},
+		Title:     title,
+		CreatedAt: formatTime(time.Now().UTC()),
+		Status:    "triggered",
+		Urgency:   "high",
+	}}
+}
+
+// Title sets the title, which is also the summary of the incident
+func (b *IncidentBuilder) Title(title string) *IncidentBuilder {
+	b.incident.Title = title
+	b.incident.Summary = title
+	return b
+}
+
+// Description sets the description, which defaults to the title
+func (b *IncidentBuilder) Description(description string) *IncidentBuilder {
+	b.incident.Description = description
+	return b
+}
+
+// Number sets the incident number
+func (b *IncidentBuilder) Number(number uint) *IncidentBuilder {
+	b.incident.IncidentNumber = number
+	return b
+}
+
+// Status sets the status: triggered, acknowledged or resolved
+func (b *IncidentBuilder) Status(status string) *IncidentBuilder {
+	b.incident.Status = status
+	return b
+}
+
+// Urgency sets the urgency: high or low
+func (b *IncidentBuilder) Urgency(urgency string) *IncidentBuilder {
+	b.incident.Urgency = urgency
+	return b
+}
+
+// Service sets the service the incident is open on
+func (b *IncidentBuilder) Service(id string) *IncidentBuilder {
+	b.incident.Service = reference("service", id)
+	return b
+}
+
+// EscalationPolicy sets the escalation policy the incident escalates along
+func (b *IncidentBuilder) EscalationPolicy(id string) *IncidentBuilder {
+	b.incident.EscalationPolicy = reference("escalation_policy", id)
+	return b
+}
+
+// CreatedAt sets the time the incident was created
+func (b *IncidentBuilder) CreatedAt(createdAt time.Time) *IncidentBuilder {
+	b.incident.CreatedAt = formatTime(createdAt)
+	return b
+}
+
+// Build returns the incident
+func (b *IncidentBuilder) Build() pagerduty.Incident {
+	incident := b.incident
+	if incident.Description == "" {
+		incident.Description = incident.Title
+	}
+	return incident
+}
+
+// UserBuilder builds a pagerduty.User, by default one with the user role in UTC
+type UserBuilder struct {
+	user pagerduty.User
+}
+
+// User starts building the user with the given ID
+func User(id string) *UserBuilder {
+	name := "User " + id
+	return &UserBuilder{user: pagerduty.User{
+		APIObject: pagerduty.APIObject{
+			ID:      id,
+			Type:    "user",
+			Summary: name,
+			Self:    "https://api.pagerduty.com/users/" + id,
+		},
+		Name:     name,
+		Email:    strings.ToLower(id) + "@example.com",
+		Timezone: "UTC",
+		Role:     "user",
+	}}
+}
+
+// Name sets the name, which is also the summary of the user
+func (b *UserBuilder) Name(name string) *UserBuilder {
+	b.user.Name = name
+	b.user.Summary = name
+	return b
+}
+
+// Email sets the email address
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+// TimeZone sets the time zone, e.g. America/New_York
+func (b *UserBuilder) TimeZone(timeZone string) *UserBuilder {
+	b.user.Timezone = timeZone
+	return b
+}
+
+// Role sets the role, e.g. admin or user
+func (b *UserBuilder) Role(role string) *UserBuilder {
+	b.user.Role = role
+	return b
+}
+
+// HTMLURL sets the address of the user in the web app of the account
+func (b *UserBuilder) HTMLURL(url string) *UserBuilder {
+	b.user.HTMLURL = url
+	return b
+}
+
+// Team adds the user to the team with the given ID and name
+func (b *UserBuilder) Team(id, name string) *UserBuilder {
+	team := pagerduty.Team{APIObject: reference("team", id)}
+	team.Summary = name
+	b.user.Teams = append(b.user.Teams, team)
+	return b
+}
+
+// Build returns the user
+func (b *UserBuilder) Build() pagerduty.User {
+	user := b.user
+	user.Teams = append([]pagerduty.Team(nil), b.user.Teams...)
+	return user
+}
+
+// OverrideBuilder builds a pagerduty.Override, by default one for the hour starting now
+type OverrideBuilder struct {
+	override pagerduty.Override
+}
+
+// Override starts building the override with the given ID
+func Override(id string) *OverrideBuilder {
+	start := time.Now().UTC().Truncate(time.Minute)
+	return &OverrideBuilder{override: pagerduty.Override{
+		ID:    id,
+		Type:  "override",
+		Start: formatTime(start),
+		End:   formatTime(start.Add(time.Hour)),
+	}}
+}
+
+// User sets the user who is on call instead
+func (b *OverrideBuilder) User(id string) *OverrideBuilder {
+	b.override.User = UserReference(id)
+	return b
+}
+
+// Between sets the start and end of the override
+func (b *OverrideBuilder) Between(start, end time.Time) *OverrideBuilder {
+	b.override.Start = formatTime(start)
+	b.override.End = formatTime(end)
+	return b
+}
+
+// Build returns the override
+func (b *OverrideBuilder) Build() pagerduty.Override {
+	return b.override
+}
+
+// ScheduleBuilder builds a pagerduty.Schedule, by default one in UTC without layers
+type ScheduleBuilder struct {
+	schedule pagerduty.Schedule
+}
+
+// Schedule starts building the schedule with the given ID, which is left empty for schedules about to be created
+func Schedule(id string) *ScheduleBuilder {
+	name := "Schedule " + id
+	return &ScheduleBuilder{schedule: pagerduty.Schedule{
+		APIObject: pagerduty.APIObject{ID: id, Type: "schedule", Summary: name},
+		Name:      name,
+		TimeZone:  "UTC",
+	}}
+}
+
+// Name sets the name, which is also the summary of the schedule
+func (b *ScheduleBuilder) Name(name string) *ScheduleBuilder {
+	b.schedule.Name = name
+	b.schedule.Summary = name
+	return b
+}
+
+// Description sets the description
+func (b *ScheduleBuilder) Description(description string) *ScheduleBuilder {
+	b.schedule.Description = description
+	return b
+}
+
+// TimeZone sets the time zone the schedule is rendered in, e.g. Europe/London
+func (b *ScheduleBuilder) TimeZone(timeZone string) *ScheduleBuilder {
+	b.schedule.TimeZone = timeZone
+	return b
+}
+
+// Team adds the schedule to the team with the given ID
+func (b *ScheduleBuilder) Team(id string) *ScheduleBuilder {
+	b.schedule.Teams = append(b.schedule.Teams, reference("team", id))
+	return b
+}
+
+// Layer adds a layer, e.g. one built with ScheduleLayer
+func (b *ScheduleBuilder) Layer(layer pagerduty.ScheduleLayer) *ScheduleBuilder {
+	b.schedule.ScheduleLayers = append(b.schedule.ScheduleLayers, layer)
+	return b
+}
+
+// Build returns the schedule
+func (b *ScheduleBuilder) Build() pagerduty.Schedule {
+	schedule := b.schedule
+	schedule.Teams = append([]pagerduty.APIObject(nil), b.schedule.Teams...)
+	schedule.ScheduleLayers = append([]pagerduty.ScheduleLayer(nil), b.schedule.ScheduleLayers...)
+	return schedule
+}
+
+// ScheduleLayerBuilder builds a pagerduty.ScheduleLayer, by default a weekly rotation starting now
+type ScheduleLayerBuilder struct {
+	layer pagerduty.ScheduleLayer
+}
+
+// ScheduleLayer starts building the layer with the given name
+func ScheduleLayer(name string) *ScheduleLayerBuilder {
+	start := formatTime(time.Now().UTC().Truncate(time.Minute))
+	return &ScheduleLayerBuilder{layer: pagerduty.ScheduleLayer{
+		Name:                      name,
+		Start:                     start,
+		RotationVirtualStart:      start,
+		RotationTurnLengthSeconds: uint((7 * 24 * time.Hour).Seconds()),
+	}}
+}
+
+// Users adds the users with the given IDs to the rotation, in the order they take their turns
+func (b *ScheduleLayerBuilder) Users(ids ...string) *ScheduleLayerBuilder {
+	for _, id := range ids {
+		b.layer.Users = append(b.layer.Users, pagerduty.UserReference{User: UserReference(id)})
+	}
+	return b
+}
+
+// Rotation sets the time the first turn of the rotation starts, and the length of every turn
+func (b *ScheduleLayerBuilder) Rotation(virtualStart time.Time, turnLength time.Duration) *ScheduleLayerBuilder {
+	b.layer.RotationVirtualStart = formatTime(virtualStart)
+	b.layer.RotationTurnLengthSeconds = uint(turnLength.Seconds())
+	return b
+}
+
+// Between sets the start of the layer and its end, which is left open when it is zero
+func (b *ScheduleLayerBuilder) Between(start, end time.Time) *ScheduleLayerBuilder {
+	b.layer.Start = formatTime(start)
+	b.layer.End = ""
+	if !end.IsZero() {
+		b.layer.End = formatTime(end)
+	}
+	return b
+}
+
+// DailyRestriction restricts the layer to duration a day, starting at startTimeOfDay, e.g. 09:00:00,
+// in the time zone of the schedule
+func (b *ScheduleLayerBuilder) DailyRestriction(startTimeOfDay string, duration time.Duration) *ScheduleLayerBuilder {
+	b.layer.Restrictions = append(b.layer.Restrictions, pagerduty.Restriction{
+		Type:            "daily_restriction",
+		StartTimeOfDay:  startTimeOfDay,
+		DurationSeconds: uint(duration.Seconds()),
+	})
+	return b
+}
+
+// WeeklyRestriction restricts the layer to duration a week, starting on day at startTimeOfDay
+// in the time zone of the schedule
+func (b *ScheduleLayerBuilder) WeeklyRestriction(day time.Weekday, startTimeOfDay string, duration time.Duration) *ScheduleLayerBuilder {
+	// PagerDuty numbers the days of the week from 1 for Monday to 7 for Sunday
+	startDayOfWeek := uint(day)
+	if day == time.Sunday {
+		startDayOfWeek = 7
+	}
+	b.layer.Restrictions = append(b.layer.Restrictions, pagerduty.Restriction{
+		Type:            "weekly_restriction",
+		StartTimeOfDay:  startTimeOfDay,
+		StartDayOfWeek:  startDayOfWeek,
+		DurationSeconds: uint(duration.Seconds()),
+	})
+	return b
+}
+
+// Build returns the layer
+func (b *ScheduleLayerBuilder) Build() pagerduty.ScheduleLayer {
+	layer := b.layer
+	layer.Users = append([]pagerduty.UserReference(nil), b.layer.Users...)
+	layer.Restrictions = append([]pagerduty.Restriction(nil), b.layer.Restrictions...)
+	return layer
+}
+
+// OnCallBuilder builds a pagerduty.OnCall, by default on the first escalation level for the day starting now
+type OnCallBuilder struct {
+	onCall pagerduty.OnCall
+}
+
+// OnCall starts building an on-call of user
+func OnCall(user pagerduty.User) *OnCallBuilder {
+	start := time.Now().UTC().Truncate(time.Minute)
+	return &OnCallBuilder{onCall: pagerduty.OnCall{
+		User:            user,
+		EscalationLevel: 1,
+		Start:           formatTime(start),
+		End:             formatTime(start.Add(24 * time.Hour)),
+	}}
+}
+
+// Schedule sets the schedule that puts the user on call
+func (b *OnCallBuilder) Schedule(schedule pagerduty.Schedule) *OnCallBuilder {
+	b.onCall.Schedule = schedule
+	return b
+}
+
+// EscalationPolicy sets the escalation policy the user is on call for
+func (b *OnCallBuilder) EscalationPolicy(policy pagerduty.EscalationPolicy) *OnCallBuilder {
+	b.onCall.EscalationPolicy = policy
+	return b
+}
+
+// Level sets the escalation level the user is on call at
+func (b *OnCallBuilder) Level(level uint) *OnCallBuilder {
+	b.onCall.EscalationLevel = level
+	return b
+}
+
+// Between sets the start and end of the on-call
+func (b *OnCallBuilder) Between(start, end time.Time) *OnCallBuilder {
+	b.onCall.Start = formatTime(start)
+	b.onCall.End = formatTime(end)
+	return b
+}
+
+// Build returns the on-call
+func (b *OnCallBuilder) Build() pagerduty.OnCall {
+	return b.onCall
+}
+
+// EscalationPolicyBuilder builds a pagerduty.EscalationPolicy, by default one without rules
+type EscalationPolicyBuilder struct {
+	policy pagerduty.EscalationPolicy
+}
+
+// EscalationPolicy starts building the escalation policy with the given ID
+func EscalationPolicy(id string) *EscalationPolicyBuilder {
+	name := "Escalation Policy " + id
+	return &EscalationPolicyBuilder{policy: pagerduty.EscalationPolicy{
+		APIObject: pagerduty.APIObject{ID: id, Type: "escalation_policy", Summary: name},
+		Name:      name,
+	}}
+}
+
+// Name sets the name, which is also the summary of the escalation policy
+func (b *EscalationPolicyBuilder) Name(name string) *EscalationPolicyBuilder {
+	b.policy.Name = name
+	b.policy.Summary = name
+	return b
+}
+
+// Rule adds a rule that escalates to targets, delay minutes after the previous rule
+func (b *EscalationPolicyBuilder) Rule(delay uint, targets ...pagerduty.APIObject) *EscalationPolicyBuilder {
+	b.policy.EscalationRules = append(b.policy.EscalationRules, pagerduty.EscalationRule{Delay: delay, Targets: targets})
+	return b
+}
+
+// Service adds the service with the given ID to the services that escalate along the policy
+func (b *EscalationPolicyBuilder) Service(id string) *EscalationPolicyBuilder {
+	b.policy.Services = append(b.policy.Services, reference("service", id))
+	return b
+}
+
+// Team adds the team with the given ID to the teams of the policy
+func (b *EscalationPolicyBuilder) Team(id string) *EscalationPolicyBuilder {
+	b.policy.Teams = append(b.policy.Teams, pagerduty.APIReference{ID: id, Type: "team_reference"})
+	return b
+}
+
+// Build returns the escalation policy
+func (b *EscalationPolicyBuilder) Build() pagerduty.EscalationPolicy {
+	policy := b.policy
+	policy.EscalationRules = append([]pagerduty.EscalationRule(nil), b.policy.EscalationRules...)
+	policy.Services = append([]pagerduty.APIObject(nil), b.policy.Services...)
+	policy.Teams = append([]pagerduty.APIReference(nil), b.policy.Teams...)
+	return policy
+}
@@ -0,0 +1,408 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// CacheResource is a kind of resource CachingClient keeps
+type CacheResource string
+
+const (
+	CacheUsers              CacheResource = "users"
+	CacheSchedules          CacheResource = "schedules"
+	CacheTags               CacheResource = "tags"
+	CacheEscalationPolicies CacheResource = "escalation_policies"
+)
+
+// CacheTTLs sets for how long CachingClient keeps each kind of resource, 0 disables caching it
+type CacheTTLs struct {
+	Users              time.Duration
+	Schedules          time.Duration
+	Tags               time.Duration
+	EscalationPolicies time.Duration
+}
+
+// DefaultCacheTTLs suits lookups of resources that change a few times a day at most
+var DefaultCacheTTLs = CacheTTLs{
+	Users:              15 * time.Minute,
+	Schedules:          15 * time.Minute,
+	Tags:               time.Hour,
+	EscalationPolicies: 15 * time.Minute,
+}
+
+// CachingClient is an IMPagerDuty decorator that caches lookups of users, schedules, tags and
+// escalation policies. Every other method is passed through to the decorated implementation
+//
+// Errors are never cached, and every caller gets its own copy of a cached result. Mutating calls invalidate
+// the resources they may have changed: overrides and schedule changes invalidate schedules and
+// UpdateEscalationPolicy invalidates escalation policies
+type CachingClient struct {
+	IMPagerDuty
+
+	ttls  CacheTTLs
+	clock Clock
+
+	mu      sync.Mutex
+	entries map[CacheResource]map[string]cacheEntry
+	// generations counts the invalidations of every resource, so that a load that was under way
+	// when its resource was invalidated does not store what it read before the change
+	generations map[CacheResource]uint64
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// NewCachingClient wraps pd with a cache that keeps each kind of resource for its TTL in ttls
+func NewCachingClient(pd IMPagerDuty, ttls CacheTTLs) *CachingClient {
+	return &CachingClient{
+		IMPagerDuty: pd,
+		ttls:        ttls,
+		clock:       SystemClock,
+		entries:     map[CacheResource]map[string]cacheEntry{},
+		generations: map[CacheResource]uint64{},
+	}
+}
+
+// SetClock makes the cache tell the time by clock when it checks whether entries expired,
+// or by SystemClock when clock is nil
+func (c *CachingClient) SetClock(clock Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = orSystemClock(clock)
+}
+
+// Invalidate drops everything cached for the given resources
+func (c *CachingClient) Invalidate(resources ...CacheResource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, resource := range resources {
+		delete(c.entries, resource)
+		c.generations[resource]++
+	}
+}
+
+// InvalidateAll drops everything cached
+func (c *CachingClient) InvalidateAll() {
+	c.Invalidate(CacheUsers, CacheSchedules, CacheTags, CacheEscalationPolicies)
+}
+
+// Mode reports the mode of the decorated implementation
+func (c *CachingClient) Mode() Mode {
+	mode, _ := ModeOf(c.IMPagerDuty)
+	return mode
+}
+
+func (c *CachingClient) ttl(resource CacheResource) time.Duration {
+	switch resource {
+	case CacheUsers:
+		return c.ttls.Users
+	case CacheSchedules:
+		return c.ttls.Schedules
+	case CacheTags:
+		return c.ttls.Tags
+	case CacheEscalationPolicies:
+		return c.ttls.EscalationPolicies
+	}
+	return 0
+}
+
+// cached returns the unexpired value stored for key, or stores and returns what load returns unless the resource
+// was invalidated while it loaded. Every caller gets its own deep copy, so changing a result never changes what later callers get
+func cached[T any](c *CachingClient, resource CacheResource, key string, load func() (T, error)) (T, error) {
+	ttl := c.ttl(resource)
+	if ttl <= 0 {
+		return load()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[resource][key]
+	now := c.clock.Now()
+	generation := c.generations[resource]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return deepCopy(entry.value.(T)), nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[resource] != generation {
+		return value, nil
+	}
+	if c.entries[resource] == nil {
+		c.entries[resource] = map[string]cacheEntry{}
+	}
+	c.entries[resource][key] = cacheEntry{value: deepCopy(value), expires: c.clock.Now().Add(ttl)}
+	return value, nil
+}
+
+// deepCopy returns a copy of value that shares no pointers, slices or maps with it.
+// Unexported fields, such as the ones of time.Time, are copied as they are
+func deepCopy[T any](value T) T {
+	return copyValue(reflect.ValueOf(&value).Elem()).Interface().(T)
+}
+
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(copyValue(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(copyValue(v.Elem()))
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(copyValue(v.Field(i)))
+			}
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// GetUserByID is served from the cache of users
+func (c *CachingClient) GetUserByID(id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	return c.GetUserByIDWithContext(context.Background(), id, options)
+}
+
+// GetUserByIDWithContext is served from the cache of users
+func (c *CachingClient) GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	return cached(c, CacheUsers, fmt.Sprintf("GetUserByID/%s/%#v", id, options), func() (*pagerduty.User, error) {
+		return c.IMPagerDuty.GetUserByIDWithContext(ctx, id, options)
+	})
+}
+
+// ListAllUsers is served from the cache of users
+func (c *CachingClient) ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
+	return c.ListAllUsersWithContext(context.Background(), options)
+}
+
+// ListAllUsersWithContext is served from the cache of users
+func (c *CachingClient) ListAllUsersWithContext(ctx context.Context, options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
+	return cached(c, CacheUsers, fmt.Sprintf("ListAllUsers/%#v", options), func() ([]pagerduty.User, error) {
+		return c.IMPagerDuty.ListAllUsersWithContext(ctx, options)
+	})
+}
+
+// GetUserIDbyName is served from the cache of users
+func (c *CachingClient) GetUserIDbyName(name string) (string, error) {
+	return c.GetUserIDbyNameWithContext(context.Background(), name)
+}
+
+// GetUserIDbyNameWithContext is served from the cache of users
+func (c *CachingClient) GetUserIDbyNameWithContext(ctx context.Context, name string) (string, error) {
+	return cached(c, CacheUsers, "GetUserIDbyName/"+name, func() (string, error) {
+		return c.IMPagerDuty.GetUserIDbyNameWithContext(ctx, name)
+	})
+}
+
+// GetUsersIDsByNames is served from the cache of users
+func (c *CachingClient) GetUsersIDsByNames(names []string) ([]string, error) {
+	return c.GetUsersIDsByNamesWithContext(context.Background(), names)
+}
+
+// GetUsersIDsByNamesWithContext is served from the cache of users
+func (c *CachingClient) GetUsersIDsByNamesWithContext(ctx context.Context, names []string) ([]string, error) {
+	if names == nil {
+		return c.IMPagerDuty.GetUsersIDsByNamesWithContext(ctx, names)
+	}
+	return cached(c, CacheUsers, "GetUsersIDsByNames/"+strings.Join(names, "\x00"), func() ([]string, error) {
+		return c.IMPagerDuty.GetUsersIDsByNamesWithContext(ctx, names)
+	})
+}
+
+// scheduleLookup is what GetScheduleIDbyName returns
+type scheduleLookup struct {
+	id       string
+	timeZone string
+}
+
+// GetScheduleIDbyName is served from the cache of schedules
+func (c *CachingClient) GetScheduleIDbyName(name string) (string, string, error) {
+	return c.GetScheduleIDbyNameWithContext(context.Background(), name)
+}
+
+// GetScheduleIDbyNameWithContext is served from the cache of schedules
+func (c *CachingClient) GetScheduleIDbyNameWithContext(ctx context.Context, name string) (string, string, error) {
+	lookup, err := cached(c, CacheSchedules, "GetScheduleIDbyName/"+name, func() (scheduleLookup, error) {
+		id, timeZone, err := c.IMPagerDuty.GetScheduleIDbyNameWithContext(ctx, name)
+		return scheduleLookup{id: id, timeZone: timeZone}, err
+	})
+	return lookup.id, lookup.timeZone, err
+}
+
+// ResolveSchedule is served from the cache of schedules
+func (c *CachingClient) ResolveSchedule(name string) (*ScheduleMatch, error) {
+	return c.ResolveScheduleWithContext(context.Background(), name)
+}
+
+// ResolveScheduleWithContext is served from the cache of schedules
+func (c *CachingClient) ResolveScheduleWithContext(ctx context.Context, name string) (*ScheduleMatch, error) {
+	return cached(c, CacheSchedules, "ResolveSchedule/"+name, func() (*ScheduleMatch, error) {
+		return c.IMPagerDuty.ResolveScheduleWithContext(ctx, name)
+	})
+}
+
+// GetScheduleByID is served from the cache of schedules
+func (c *CachingClient) GetScheduleByID(id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	return c.GetScheduleByIDWithContext(context.Background(), id, options)
+}
+
+// GetScheduleByIDWithContext is served from the cache of schedules
+func (c *CachingClient) GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	return cached(c, CacheSchedules, fmt.Sprintf("GetScheduleByID/%s/%#v", id, options), func() (*pagerduty.Schedule, error) {
+		return c.IMPagerDuty.GetScheduleByIDWithContext(ctx, id, options)
+	})
+}
+
+// ListSchedules is served from the cache of schedules
+func (c *CachingClient) ListSchedules(options ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	return c.ListSchedulesWithContext(context.Background(), options)
+}
+
+// ListSchedulesWithContext is served from the cache of schedules
+func (c *CachingClient) ListSchedulesWithContext(ctx context.Context, options ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	return cached(c, CacheSchedules, fmt.Sprintf("ListSchedules/%#v", options), func() ([]pagerduty.Schedule, error) {
+		return c.IMPagerDuty.ListSchedulesWithContext(ctx, options)
+	})
+}
+
+// ListAllTags is served from the cache of tags
+func (c *CachingClient) ListAllTags(options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
+	return c.ListAllTagsWithContext(context.Background(), options)
+}
+
+// ListAllTagsWithContext is served from the cache of tags
+func (c *CachingClient) ListAllTagsWithContext(ctx context.Context, options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
+	return cached(c, CacheTags, fmt.Sprintf("ListAllTags/%#v", options), func() ([]*pagerduty.Tag, error) {
+		return c.IMPagerDuty.ListAllTagsWithContext(ctx, options)
+	})
+}
+
+// GetEscalationPoliciesByTag is served from the cache of escalation policies
+func (c *CachingClient) GetEscalationPoliciesByTag(tagID string) (*pagerduty.ListEPResponse, error) {
+	return c.GetEscalationPoliciesByTagWithContext(context.Background(), tagID)
+}
+
+// GetEscalationPoliciesByTagWithContext is served from the cache of escalation policies
+func (c *CachingClient) GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (*pagerduty.ListEPResponse, error) {
+	return cached(c, CacheEscalationPolicies, "GetEscalationPoliciesByTag/"+tagID, func() (*pagerduty.ListEPResponse, error) {
+		return c.IMPagerDuty.GetEscalationPoliciesByTagWithContext(ctx, tagID)
+	})
+}
+
+// CreateOverride invalidates the cache of schedules
+func (c *CachingClient) CreateOverride(scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	return c.CreateOverrideWithContext(context.Background(), scheduleID, userID, start, end)
+}
+
+// CreateOverrideWithContext invalidates the cache of schedules
+func (c *CachingClient) CreateOverrideWithContext(ctx context.Context, scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	defer c.Invalidate(CacheSchedules)
+	return c.IMPagerDuty.CreateOverrideWithContext(ctx, scheduleID, userID, start, end)
+}
+
+// RemoveOverride invalidates the cache of schedules
+func (c *CachingClient) RemoveOverride(scheduleID string, overrideID string) error {
+	return c.RemoveOverrideWithContext(context.Background(), scheduleID, overrideID)
+}
+
+// RemoveOverrideWithContext invalidates the cache of schedules
+func (c *CachingClient) RemoveOverrideWithContext(ctx context.Context, scheduleID string, overrideID string) error {
+	defer c.Invalidate(CacheSchedules)
+	return c.IMPagerDuty.RemoveOverrideWithContext(ctx, scheduleID, overrideID)
+}
+
+// CreateSchedule invalidates the cache of schedules
+func (c *CachingClient) CreateSchedule(schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return c.CreateScheduleWithContext(context.Background(), schedule)
+}
+
+// CreateScheduleWithContext invalidates the cache of schedules
+func (c *CachingClient) CreateScheduleWithContext(ctx context.Context, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	defer c.Invalidate(CacheSchedules)
+	return c.IMPagerDuty.CreateScheduleWithContext(ctx, schedule)
+}
+
+// UpdateSchedule invalidates the cache of schedules
+func (c *CachingClient) UpdateSchedule(id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return c.UpdateScheduleWithContext(context.Background(), id, schedule)
+}
+
+// UpdateScheduleWithContext invalidates the cache of schedules
+func (c *CachingClient) UpdateScheduleWithContext(ctx context.Context, id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	defer c.Invalidate(CacheSchedules)
+	return c.IMPagerDuty.UpdateScheduleWithContext(ctx, id, schedule)
+}
+
+// DeleteSchedule invalidates the cache of schedules
+func (c *CachingClient) DeleteSchedule(id string) error {
+	return c.DeleteScheduleWithContext(context.Background(), id)
+}
+
+// DeleteScheduleWithContext invalidates the cache of schedules
+func (c *CachingClient) DeleteScheduleWithContext(ctx context.Context, id string) error {
+	defer c.Invalidate(CacheSchedules)
+	return c.IMPagerDuty.DeleteScheduleWithContext(ctx, id)
+}
+
+// UpdateEscalationPolicy invalidates the cache of escalation policies
+func (c *CachingClient) UpdateEscalationPolicy(id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	return c.UpdateEscalationPolicyWithContext(context.Background(), id, userID, serviceID, teamID, escalation)
+}
+
+// UpdateEscalationPolicyWithContext invalidates the cache of escalation policies
+func (c *CachingClient) UpdateEscalationPolicyWithContext(ctx context.Context, id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	defer c.Invalidate(CacheEscalationPolicies)
+	return c.IMPagerDuty.UpdateEscalationPolicyWithContext(ctx, id, userID, serviceID, teamID, escalation)
+}
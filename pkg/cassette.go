@@ -0,0 +1,249 @@
+package mPagerDuty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// redactedHeaders are never written to cassettes, as they carry credentials
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// timeWindowParams bound the time window of a query
+var timeWindowParams = []string{"since", "until"}
+
+// clockWindowPaths are the paths whose time window the client computes from the clock rather than takes
+// from the caller, like the incident lookups of GetIndicentsByEscalationPolicy, GetIndicentsByTag and SearchIncidents
+var clockWindowPaths = map[string]bool{"/incidents": true}
+
+// WithCassette records every request the client sends, and the response it gets, into the cassette file at path.
+// In ModeReplay the client instead answers requests from the cassette without sending them, failing requests
+// that no recorded interaction matches by method, path and query. Incident lookups whose query only differs
+// in its since and until parameters still match, as their window follows the clock, so they replay at any time
+func WithCassette(path string) ClientOption {
+	return func(o *clientOptions) {
+		o.cassette = path
+	}
+}
+
+// cassette is the JSON document a cassette file holds
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// matches reports whether req is the recorded request, ignoring its headers and body,
+// and also the time window of its query unless exact is set and the client computed the window from the clock
+func (r recordedRequest) matches(req *http.Request, exact bool) bool {
+	if r.Method != req.Method || r.Path != req.URL.Path {
+		return false
+	}
+	if exact || req.Method != http.MethodGet || !clockWindowPaths[req.URL.Path] {
+		return r.Query == req.URL.Query().Encode()
+	}
+	recorded, err := url.ParseQuery(r.Query)
+	if err != nil {
+		return false
+	}
+	return withoutTimeWindow(recorded) == withoutTimeWindow(req.URL.Query())
+}
+
+// withoutTimeWindow drops the timeWindowParams from query and encodes what is left
+func withoutTimeWindow(query url.Values) string {
+	for _, param := range timeWindowParams {
+		query.Del(param)
+	}
+	return query.Encode()
+}
+
+// useCassette makes the client record into the cassette, or replay it in ModeReplay
+func (o *clientOptions) useCassette(mode Mode) error {
+	if mode != ModeReplay {
+		recorder := &recordingTransport{path: o.cassette}
+		if err := recorder.save(); err != nil {
+			return err
+		}
+		o.cassetteTransport = func(base http.RoundTripper) http.RoundTripper {
+			recorder.base = base
+			return recorder
+		}
+		return nil
+	}
+
+	data, err := os.ReadFile(o.cassette)
+	if err != nil {
+		return fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var replayed cassette
+	if err := json.Unmarshal(data, &replayed); err != nil {
+		return fmt.Errorf("%w: failed to parse cassette '%s': %v", ErrInvalidArgument, o.cassette, err)
+	}
+	replayer := &replayTransport{interactions: replayed.Interactions, used: make([]bool, len(replayed.Interactions))}
+	o.cassetteTransport = func(http.RoundTripper) http.RoundTripper {
+		return replayer
+	}
+	return nil
+}
+
+// recordingTransport sends requests through base and writes every interaction to the cassette at path
+type recordingTransport struct {
+	base http.RoundTripper
+	path string
+
+	mu       sync.Mutex
+	cassette cassette
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestBody, body, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, body, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = body
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction{
+		Request: recordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.Query().Encode(),
+			Header: redact(req.Header),
+			Body:   requestBody,
+		},
+		Response: recordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redact(resp.Header),
+			Body:       responseBody,
+		},
+	})
+	if err := t.save(); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save rewrites the cassette file, so that it is complete whenever the client stops
+func (t *recordingTransport) save() error {
+	if t.cassette.Interactions == nil {
+		t.cassette.Interactions = []interaction{}
+	}
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(t.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// readBody reads and closes body, returning what was read along with a body that reads it again
+func readBody(body io.ReadCloser) (string, io.ReadCloser, error) {
+	if body == nil || body == http.NoBody {
+		return "", body, nil
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", nil, err
+	}
+	return string(data), io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func redact(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, "REDACTED")
+		}
+	}
+	return header
+}
+
+// replayTransport answers every request with the response of the first recorded interaction
+// that matches it and has not answered a request yet, preferring interactions that match its time window too
+type replayTransport struct {
+	mu           sync.Mutex
+	interactions []interaction
+	used         []bool
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	i := t.find(req, true)
+	if i < 0 {
+		i = t.find(req, false)
+	}
+	if i >= 0 {
+		t.used[i] = true
+
+		response := t.interactions[i].Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+			StatusCode:    response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left for %s %s?%s", req.Method, req.URL.Path, req.URL.Query().Encode())
+}
+
+// find returns the index of the first unused interaction that matches req, or -1
+func (t *replayTransport) find(req *http.Request, exact bool) int {
+	for i, recorded := range t.interactions {
+		if !t.used[i] && recorded.Request.matches(req, exact) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,68 @@
+package mPagerDuty
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells clients the time. Everything that computes time windows, such as the since
+// window of GetIndicentsByEscalationPolicy or the expiry of cached lookups, asks the clock
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is the clock of the machine, which clients use unless told otherwise
+var SystemClock Clock = systemClock{}
+
+// TestClock is a Clock that stands still until it is advanced or set. It is safe for concurrent use
+type TestClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewTestClock returns a TestClock that reads now
+func NewTestClock(now time.Time) *TestClock {
+	return &TestClock{now: now}
+}
+
+// Now returns the time the clock reads
+func (c *TestClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d, or backward when d is negative
+func (c *TestClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set makes the clock read now
+func (c *TestClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// WithClock makes the client, and the faked client of ModeFake, tell the time by clock
+func WithClock(clock Clock) ClientOption {
+	return func(o *clientOptions) {
+		o.clock = orSystemClock(clock)
+	}
+}
+
+// orSystemClock returns clock, or SystemClock when clock is nil
+func orSystemClock(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
@@ -0,0 +1,118 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the account specific settings of a client
+type Config struct {
+	// SchedulePrefix is prepended to the names passed to GetScheduleIDbyName, separated by a space.
+	// ResolveSchedule matches names with or without it
+	SchedulePrefix string `yaml:"schedule_prefix" json:"schedule_prefix"`
+	// TeamIDs restricts GetUserIDbyName to members of these teams, all users are searched when empty
+	TeamIDs []string `yaml:"team_ids" json:"team_ids"`
+	// PageSize is the number of items requested per page, PagerDuty allows at most 100
+	PageSize uint `yaml:"page_size" json:"page_size"`
+	// DefaultTimeZone is the IANA time zone the API renders times in
+	DefaultTimeZone string `yaml:"default_time_zone" json:"default_time_zone"`
+	// RequesterEmail is the email address of the PagerDuty user that requests such as CreateIncident are made on behalf of
+	RequesterEmail string `yaml:"requester_email" json:"requester_email"`
+}
+
+// DefaultConfig returns the settings used for anything that is not configured explicitly
+func DefaultConfig() Config {
+	return Config{
+		PageSize:        limit,
+		DefaultTimeZone: "UTC",
+		RequesterEmail:  defaultFrom,
+	}
+}
+
+// LoadConfigFromEnv returns DefaultConfig overridden by the following environment variables:
+//
+//	PD_SCHEDULEPREFIX   schedule name prefix
+//	PD_TEAMID           comma separated team IDs
+//	PD_PAGESIZE         page size
+//	PD_TIMEZONE         default time zone
+//	PD_REQUESTER_EMAIL  requester email
+func LoadConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	config.SchedulePrefix = os.Getenv("PD_SCHEDULEPREFIX")
+	config.TeamIDs = splitList(os.Getenv("PD_TEAMID"))
+	if pageSize := os.Getenv("PD_PAGESIZE"); pageSize != "" {
+		size, err := strconv.ParseUint(pageSize, 10, 32)
+		if err != nil {
+			return Config{}, fmt.Errorf("%w: PD_PAGESIZE must be a positive number: %v", ErrInvalidArgument, err)
+		}
+		config.PageSize = uint(size)
+	}
+	if timeZone := os.Getenv("PD_TIMEZONE"); timeZone != "" {
+		config.DefaultTimeZone = timeZone
+	}
+	if email := os.Getenv("PD_REQUESTER_EMAIL"); email != "" {
+		config.RequesterEmail = email
+	}
+
+	return config, config.Validate()
+}
+
+// LoadConfigFromYAML returns DefaultConfig overridden by the settings in the YAML file at path
+func LoadConfigFromYAML(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	config := DefaultConfig()
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, fmt.Errorf("%w: failed to parse config file '%s': %v", ErrInvalidArgument, path, err)
+	}
+
+	return config, config.Validate()
+}
+
+// Validate reports settings PagerDuty would reject
+func (config Config) Validate() error {
+	if config.PageSize < 1 || config.PageSize > limit {
+		return fmt.Errorf("%w: page size must be between 1 and %d", ErrInvalidArgument, limit)
+	}
+	if _, err := time.LoadLocation(config.DefaultTimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone '%s': %v", ErrInvalidArgument, config.DefaultTimeZone, err)
+	}
+	if strings.TrimSpace(config.RequesterEmail) == "" {
+		return fmt.Errorf("%w: requester email must be specified", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// WithConfig makes the client use config instead of the one LoadConfigFromEnv returns
+func WithConfig(config Config) ClientOption {
+	return func(o *clientOptions) {
+		o.config = &config
+	}
+}
+
+// scheduleTitle returns the full name of the schedule called name
+func (config Config) scheduleTitle(name string) string {
+	if config.SchedulePrefix == "" {
+		return name
+	}
+	return config.SchedulePrefix + " " + name
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,516 @@
+// Package conformance holds behavioral checks that every mPagerDuty.IMPagerDuty has to pass,
+// so that the live client, the fake client and the emulator cannot drift apart unnoticed
+package conformance
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// Target names the resources the client under test is known to hold. Checks that need a
+// resource the target leaves empty are skipped
+type Target struct {
+	// UserID and UserName identify a user, OtherUserID and OtherUserName a second one
+	UserID        string
+	UserName      string
+	OtherUserID   string
+	OtherUserName string
+
+	// ScheduleID identifies a schedule, called ScheduleName in the time zone ScheduleTimeZone
+	ScheduleID       string
+	ScheduleName     string
+	ScheduleTimeZone string
+
+	// EscalationPolicyID identifies an escalation policy tagged with the tag TagID labelled TagName
+	EscalationPolicyID string
+	TagID              string
+	TagName            string
+
+	// ServiceID identifies a service incidents can be created on
+	ServiceID string
+
+	// Changes enables the checks that create and remove schedules, overrides and incidents
+	Changes bool
+}
+
+// NewClient returns the client under test. It is called once for every check
+type NewClient func(t *testing.T) mPagerDuty.IMPagerDuty
+
+// unknownID is an ID no PagerDuty resource has
+const unknownID = "PNOSUCH0"
+
+// Run runs every check against the clients newClient returns, each as a subtest of t
+func Run(t *testing.T, newClient NewClient, target Target) {
+	checks := []struct {
+		name  string
+		check func(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target)
+	}{
+		{"InvalidArguments", checkInvalidArguments},
+		{"CancelledContext", checkCancelledContext},
+		{"Users", checkUsers},
+		{"UsersIDsByNames", checkUsersIDsByNames},
+		{"Schedules", checkSchedules},
+		{"ScheduleLifecycle", checkScheduleLifecycle},
+		{"RenderedSchedule", checkRenderedSchedule},
+		{"OnCalls", checkOnCalls},
+		{"OnCallsAt", checkOnCallsAt},
+		{"Overrides", checkOverrides},
+		{"Tags", checkTags},
+		{"Incidents", checkIncidents},
+		{"EscalationPolicies", checkEscalationPolicies},
+	}
+
+	for _, c := range checks {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			c.check(t, newClient(t), target)
+		})
+	}
+}
+
+// requireTarget skips the check unless the target names every resource it needs
+func requireTarget(t *testing.T, fields ...string) {
+	t.Helper()
+	for _, field := range fields {
+		if field == "" {
+			t.Skip("the target does not name every resource this check needs")
+		}
+	}
+}
+
+// requireChanges skips the check unless the target allows creating and removing resources
+func requireChanges(t *testing.T, target Target) {
+	t.Helper()
+	if !target.Changes {
+		t.Skip("the target does not allow changes")
+	}
+}
+
+func checkInvalidArguments(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	now := time.Now()
+	since := now.UTC().Format(time.RFC3339)
+	until := now.Add(time.Hour).UTC().Format(time.RFC3339)
+	layer := builder.ScheduleLayer("Layer").Users(unknownID).Build()
+
+	calls := map[string]func() error{
+		"GetOnCallsByScheduleIDs nil":   func() error { _, err := mPD.GetOnCallsByScheduleIDs(nil); return err },
+		"GetOnCallsByScheduleIDs empty": func() error { _, err := mPD.GetOnCallsByScheduleIDs([]string{}); return err },
+		"GetOnCallsByScheduleIDs blank": func() error { _, err := mPD.GetOnCallsByScheduleIDs([]string{unknownID, " "}); return err },
+		"GetOnCallsAt ids":              func() error { _, err := mPD.GetOnCallsAt(nil, nil, now); return err },
+		"GetOnCallsAt blank":            func() error { _, err := mPD.GetOnCallsAt([]string{unknownID}, []string{" "}, now); return err },
+		"GetOnCallsAt time":             func() error { _, err := mPD.GetOnCallsAt([]string{unknownID}, nil, time.Time{}); return err },
+		"GetScheduleIDbyName":           func() error { _, _, err := mPD.GetScheduleIDbyName("  "); return err },
+		"ResolveSchedule":               func() error { _, err := mPD.ResolveSchedule("  "); return err },
+		"GetUserIDbyName":               func() error { _, err := mPD.GetUserIDbyName("  "); return err },
+		"GetUserByID":                   func() error { _, err := mPD.GetUserByID("  ", pagerduty.GetUserOptions{}); return err },
+		"GetUsersIDsByNames nil":        func() error { _, err := mPD.GetUsersIDsByNames(nil); return err },
+		"GetUsersIDsByNames empty":      func() error { _, err := mPD.GetUsersIDsByNames([]string{}); return err },
+		"GetUsersIDsByNames blank":      func() error { _, err := mPD.GetUsersIDsByNames([]string{"Nobody", ""}); return err },
+		"GetOverrides schedule":         func() error { _, err := mPD.GetOverrides(" ", since, until, false); return err },
+		"GetOverrides dates":            func() error { _, err := mPD.GetOverrides(unknownID, "", until, false); return err },
+		"CreateOverride schedule":       func() error { _, err := mPD.CreateOverride(" ", unknownID, since, until); return err },
+		"CreateOverride user":           func() error { _, err := mPD.CreateOverride(unknownID, " ", since, until); return err },
+		"CreateOverride start":          func() error { _, err := mPD.CreateOverride(unknownID, unknownID, " ", until); return err },
+		"CreateOverride end":            func() error { _, err := mPD.CreateOverride(unknownID, unknownID, since, " "); return err },
+		"RemoveOverride schedule":       func() error { return mPD.RemoveOverride(" ", unknownID) },
+		"RemoveOverride override":       func() error { return mPD.RemoveOverride(unknownID, " ") },
+		"GetScheduleByID":               func() error { _, err := mPD.GetScheduleByID(" ", pagerduty.GetScheduleOptions{}); return err },
+		"CreateSchedule time zone": func() error {
+			_, err := mPD.CreateSchedule(builder.Schedule("").TimeZone("").Layer(layer).Build())
+			return err
+		},
+		"CreateSchedule layers": func() error { _, err := mPD.CreateSchedule(builder.Schedule("").Build()); return err },
+		"UpdateSchedule id":     func() error { _, err := mPD.UpdateSchedule(" ", builder.Schedule("").Layer(layer).Build()); return err },
+		"UpdateSchedule layers": func() error { _, err := mPD.UpdateSchedule(unknownID, builder.Schedule("").Build()); return err },
+		"DeleteSchedule":        func() error { return mPD.DeleteSchedule(" ") },
+		"GetRenderedSchedule id": func() error {
+			_, err := mPD.GetRenderedSchedule(" ", now, now.Add(time.Hour), "")
+			return err
+		},
+		"GetRenderedSchedule window": func() error {
+			_, err := mPD.GetRenderedSchedule(unknownID, now, now.Add(-time.Hour), "")
+			return err
+		},
+		"GetRenderedSchedule time zone": func() error {
+			_, err := mPD.GetRenderedSchedule(unknownID, now, now.Add(time.Hour), "Nowhere/Nowhere")
+			return err
+		},
+		"GetIndicentsByEscalationPolicy": func() error {
+			_, err := mPD.GetIndicentsByEscalationPolicy(" ", -30)
+			return err
+		},
+		"GetIndicentsByTag":           func() error { _, err := mPD.GetIndicentsByTag(" ", -30); return err },
+		"CreateIncident title":        func() error { _, err := mPD.CreateIncident(" ", unknownID, "", "", ""); return err },
+		"CreateIncident service":      func() error { _, err := mPD.CreateIncident("title", " ", "", "", ""); return err },
+		"SearchIncidentLogs incident": func() error { _, err := mPD.SearchIncidentLogs(" ", "trigger_log_entry"); return err },
+		"SearchIncidentLogs type":     func() error { _, err := mPD.SearchIncidentLogs(unknownID, " "); return err },
+		"GetEscalationPoliciesByTag":  func() error { _, err := mPD.GetEscalationPoliciesByTag(" "); return err },
+		"UpdateEscalationPolicy id": func() error {
+			_, err := mPD.UpdateEscalationPolicy(" ", unknownID, "", "", []pagerduty.APIObject{})
+			return err
+		},
+		"UpdateEscalationPolicy user": func() error {
+			_, err := mPD.UpdateEscalationPolicy(unknownID, " ", "", "", []pagerduty.APIObject{})
+			return err
+		},
+		"UpdateEscalationPolicy escalation": func() error {
+			_, err := mPD.UpdateEscalationPolicy(unknownID, unknownID, "", "", nil)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		err := call()
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: expected ErrInvalidArgument, got %v", name, err)
+	}
+}
+
+func checkCancelledContext(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := mPD.GetOnCallsByScheduleIDsWithContext(ctx, []string{unknownID})
+	assert.True(t, errors.Is(err, context.Canceled), "GetOnCallsByScheduleIDs: %v", err)
+	_, err = mPD.ListAllUsersWithContext(ctx, pagerduty.ListUsersOptions{})
+	assert.True(t, errors.Is(err, context.Canceled), "ListAllUsers: %v", err)
+	_, _, err = mPD.GetScheduleIDbyNameWithContext(ctx, "Nobody")
+	assert.True(t, errors.Is(err, context.Canceled), "GetScheduleIDbyName: %v", err)
+	_, err = mPD.ResolveScheduleWithContext(ctx, "Nobody")
+	assert.True(t, errors.Is(err, context.Canceled), "ResolveSchedule: %v", err)
+	_, err = mPD.SearchIncidentsWithContext(ctx, "Nothing", -30)
+	assert.True(t, errors.Is(err, context.Canceled), "SearchIncidents: %v", err)
+	_, err = mPD.ListAllTagsWithContext(ctx, pagerduty.ListTagOptions{})
+	assert.True(t, errors.Is(err, context.Canceled), "ListAllTags: %v", err)
+}
+
+func checkUsers(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.UserID, target.UserName)
+
+	user, err := mPD.GetUserByID(target.UserID, pagerduty.GetUserOptions{})
+	if assert.Nil(t, err) {
+		assert.Equal(t, target.UserID, user.ID)
+		assert.Equal(t, target.UserName, user.Name)
+	}
+	_, err = mPD.GetUserByID(unknownID, pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "GetUserByID of an unknown user: %v", err)
+
+	id, err := mPD.GetUserIDbyName(strings.ToUpper(target.UserName))
+	assert.Nil(t, err)
+	assert.Equal(t, target.UserID, id, "user names match regardless of case")
+	id, err = mPD.GetUserIDbyName("Nobody At All")
+	assert.Nil(t, err)
+	assert.Empty(t, id)
+
+	users, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, userIDs(users), target.UserID)
+}
+
+func checkUsersIDsByNames(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.UserID, target.UserName, target.OtherUserID, target.OtherUserName)
+
+	ids, err := mPD.GetUsersIDsByNames([]string{target.UserName, "Nobody At All", target.OtherUserName})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{target.UserID, target.OtherUserID}, ids, "one ID for every known name, in order")
+}
+
+func checkSchedules(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.ScheduleID, target.ScheduleName)
+
+	id, timeZone, err := mPD.GetScheduleIDbyName(strings.ToLower(target.ScheduleName))
+	assert.Nil(t, err)
+	assert.Equal(t, target.ScheduleID, id, "schedule names match regardless of case")
+	if target.ScheduleTimeZone != "" {
+		assert.Equal(t, target.ScheduleTimeZone, timeZone)
+	}
+
+	id, timeZone, err = mPD.GetScheduleIDbyName("No Such Schedule")
+	assert.Nil(t, err)
+	assert.Empty(t, id)
+	assert.Empty(t, timeZone)
+
+	match, err := mPD.ResolveSchedule(strings.ToUpper(target.ScheduleName))
+	if assert.Nil(t, err) {
+		assert.Equal(t, target.ScheduleID, match.ID)
+		assert.True(t, match.Exact, "schedule names resolve regardless of case")
+	}
+	_, err = mPD.ResolveSchedule("No Such Schedule Anywhere")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "ResolveSchedule of an unknown name: %v", err)
+
+	schedule, err := mPD.GetScheduleByID(target.ScheduleID, pagerduty.GetScheduleOptions{})
+	if assert.Nil(t, err) {
+		assert.Equal(t, target.ScheduleID, schedule.ID)
+		assert.Equal(t, target.ScheduleName, schedule.Name)
+	}
+	_, err = mPD.GetScheduleByID(unknownID, pagerduty.GetScheduleOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "GetScheduleByID of an unknown schedule: %v", err)
+
+	schedules, err := mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{Query: strings.ToUpper(target.ScheduleName)})
+	assert.Nil(t, err)
+	assert.Contains(t, scheduleIDs(schedules), target.ScheduleID, "schedule queries match regardless of case")
+	schedules, err = mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{TeamIDs: []string{unknownID}})
+	assert.Nil(t, err)
+	assert.Empty(t, schedules)
+}
+
+func checkRenderedSchedule(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.ScheduleID)
+
+	since := time.Now().Truncate(time.Hour)
+	until := since.Add(7 * 24 * time.Hour)
+	rendered, err := mPD.GetRenderedSchedule(target.ScheduleID, since, until, "Asia/Tokyo")
+	if assert.Nil(t, err) {
+		assert.Equal(t, target.ScheduleID, rendered.ScheduleID)
+		assert.Equal(t, "Asia/Tokyo", rendered.TimeZone)
+		checkRenderedEntries(t, "final", rendered.Final, since, until)
+		checkRenderedEntries(t, "overrides", rendered.Overrides, since, until)
+		for _, layer := range rendered.Layers {
+			checkRenderedEntries(t, "layer "+layer.Name, layer.Entries, since, until)
+		}
+	}
+
+	_, err = mPD.GetRenderedSchedule(unknownID, since, until, "")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "GetRenderedSchedule of an unknown schedule: %v", err)
+}
+
+// checkRenderedEntries checks that entries are sorted, in the window, in the requested time zone and merged
+func checkRenderedEntries(t *testing.T, name string, entries []mPagerDuty.RenderedEntry, since, until time.Time) {
+	for i, entry := range entries {
+		assert.True(t, entry.End.After(entry.Start), "%s: entry %d ends after it starts", name, i)
+		assert.False(t, entry.Start.Before(since) || entry.End.After(until), "%s: entry %d is in the window", name, i)
+		assert.Equal(t, "Asia/Tokyo", entry.Start.Location().String(), "%s: entry %d", name, i)
+		if i > 0 {
+			previous := entries[i-1]
+			assert.False(t, entry.Start.Before(previous.Start), "%s: entry %d is sorted", name, i)
+			assert.False(t, previous.UserID == entry.UserID && !entry.Start.After(previous.End),
+				"%s: entry %d is merged with the entry before it", name, i)
+		}
+	}
+}
+
+func checkScheduleLifecycle(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.UserID, target.OtherUserID)
+	requireChanges(t, target)
+
+	// the layer starts a year ahead, so the schedule puts nobody on call today
+	start := time.Now().UTC().AddDate(1, 0, 0).Truncate(time.Hour)
+	name := "Conformance " + start.Format(time.RFC3339)
+	layer := builder.ScheduleLayer("Weekly").Users(target.UserID).Rotation(start, 7*24*time.Hour).Between(start, time.Time{})
+
+	schedule, err := mPD.CreateSchedule(builder.Schedule("").Name(name).TimeZone("Europe/London").Layer(layer.Build()).Build())
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotEmpty(t, schedule.ID)
+	assert.Equal(t, name, schedule.Name)
+	assert.Equal(t, "Europe/London", schedule.TimeZone)
+
+	fetched, err := mPD.GetScheduleByID(schedule.ID, pagerduty.GetScheduleOptions{})
+	if assert.Nil(t, err) && assert.Len(t, fetched.ScheduleLayers, 1) {
+		assert.Equal(t, target.UserID, fetched.ScheduleLayers[0].Users[0].User.ID)
+	}
+	schedules, err := mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{Query: name})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{schedule.ID}, scheduleIDs(schedules))
+
+	update := builder.Schedule("").Name(name + " Updated").TimeZone("UTC").Layer(layer.Users(target.OtherUserID).Build()).Build()
+	updated, err := mPD.UpdateSchedule(schedule.ID, update)
+	if assert.Nil(t, err) {
+		assert.Equal(t, schedule.ID, updated.ID)
+		assert.Equal(t, name+" Updated", updated.Name)
+		assert.Equal(t, "UTC", updated.TimeZone)
+	}
+	fetched, err = mPD.GetScheduleByID(schedule.ID, pagerduty.GetScheduleOptions{})
+	if assert.Nil(t, err) && assert.Len(t, fetched.ScheduleLayers, 1) {
+		assert.Len(t, fetched.ScheduleLayers[0].Users, 2, "updates replace the layers")
+	}
+
+	assert.Nil(t, mPD.DeleteSchedule(schedule.ID))
+	_, err = mPD.GetScheduleByID(schedule.ID, pagerduty.GetScheduleOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "GetScheduleByID of a deleted schedule: %v", err)
+
+	err = mPD.DeleteSchedule(unknownID)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "DeleteSchedule of an unknown schedule: %v", err)
+	_, err = mPD.UpdateSchedule(unknownID, update)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "UpdateSchedule of an unknown schedule: %v", err)
+}
+
+func checkOnCalls(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.ScheduleID, target.UserID)
+
+	onCalls, err := mPD.GetOnCallsByScheduleIDs([]string{target.ScheduleID})
+	assert.Nil(t, err)
+	for _, onCall := range onCalls {
+		assert.Equal(t, target.ScheduleID, onCall.Schedule.ID)
+	}
+
+	response, err := mPD.GetOnCallsWithOptions(&pagerduty.ListOnCallOptions{UserIDs: []string{target.UserID}})
+	if assert.Nil(t, err) {
+		for _, onCall := range response.OnCalls {
+			assert.Equal(t, target.UserID, onCall.User.ID)
+		}
+	}
+}
+
+func checkOnCallsAt(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.ScheduleID)
+
+	at := time.Now()
+	levels, err := mPD.GetOnCallsAt([]string{target.ScheduleID}, nil, at)
+	assert.Nil(t, err)
+	for i, level := range levels {
+		assert.NotEmpty(t, level.EscalationPolicyID)
+		assert.GreaterOrEqual(t, level.Level, uint(1), "escalation levels count from 1")
+		if i > 0 && levels[i-1].EscalationPolicyID == level.EscalationPolicyID {
+			assert.Less(t, levels[i-1].Level, level.Level, "levels are sorted")
+		}
+		for _, user := range level.Users {
+			assert.Equal(t, target.ScheduleID, user.ScheduleID)
+			assert.False(t, user.Start.After(at), "%s is on call at the time asked for", user.UserID)
+			assert.True(t, user.End.IsZero() || user.End.After(at), "%s is on call at the time asked for", user.UserID)
+		}
+	}
+}
+
+func checkOverrides(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.ScheduleID, target.UserID)
+	requireChanges(t, target)
+
+	// a whole hour a year ahead, so the override touches nobody's shift today
+	start := time.Now().UTC().AddDate(1, 0, 0).Truncate(time.Hour)
+	end := start.Add(time.Hour)
+	since := start.Add(-time.Hour).Format(time.RFC3339)
+	until := end.Add(time.Hour).Format(time.RFC3339)
+
+	override, err := mPD.CreateOverride(target.ScheduleID, target.UserID, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.NotEmpty(t, override.ID)
+	assert.Equal(t, target.UserID, override.User.ID)
+
+	overrides, err := mPD.GetOverrides(target.ScheduleID, since, until, false)
+	if assert.Nil(t, err) {
+		assert.Contains(t, overrideIDs(overrides.Overrides), override.ID)
+	}
+
+	assert.Nil(t, mPD.RemoveOverride(target.ScheduleID, override.ID))
+	overrides, err = mPD.GetOverrides(target.ScheduleID, since, until, false)
+	if assert.Nil(t, err) {
+		assert.NotContains(t, overrideIDs(overrides.Overrides), override.ID)
+	}
+
+	err = mPD.RemoveOverride(target.ScheduleID, unknownID)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "RemoveOverride of an unknown override: %v", err)
+}
+
+func checkTags(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.TagID, target.TagName)
+
+	tags, err := mPD.ListAllTags(pagerduty.ListTagOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, tagIDs(tags), target.TagID)
+
+	tags, err = mPD.ListAllTags(pagerduty.ListTagOptions{Query: target.TagName})
+	assert.Nil(t, err)
+	assert.Contains(t, tagIDs(tags), target.TagID)
+
+	_, err = mPD.GetIndicentsByTag("no such tag at all", -30)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "GetIndicentsByTag of an unknown tag: %v", err)
+}
+
+func checkIncidents(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.EscalationPolicyID)
+
+	incidents, err := mPD.GetIndicentsByEscalationPolicy(target.EscalationPolicyID, -30*24*60)
+	assert.Nil(t, err)
+	for _, incident := range incidents {
+		assert.Equal(t, target.EscalationPolicyID, incident.EscalationPolicy.ID)
+	}
+
+	requireTarget(t, target.ServiceID)
+	requireChanges(t, target)
+
+	incident, err := mPD.CreateIncident("Conformance check", target.ServiceID, "low", "", target.EscalationPolicyID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "triggered", incident.Status)
+	assert.Equal(t, "low", incident.Urgency)
+
+	incidents, err = mPD.GetIndicentsByEscalationPolicy(target.EscalationPolicyID, -60)
+	assert.Nil(t, err)
+	assert.Contains(t, incidentIDs(incidents), incident.ID)
+
+	summary, err := mPD.SearchIncidentLogs(incident.ID, "trigger_log_entry")
+	assert.Nil(t, err)
+	assert.NotNil(t, summary)
+	summary, err = mPD.SearchIncidentLogs(incident.ID, "no_such_log_entry")
+	assert.Nil(t, err)
+	assert.Nil(t, summary)
+}
+
+func checkEscalationPolicies(t *testing.T, mPD mPagerDuty.IMPagerDuty, target Target) {
+	requireTarget(t, target.TagID, target.EscalationPolicyID, target.UserID)
+
+	policies, err := mPD.GetEscalationPoliciesByTag(target.TagID)
+	if assert.Nil(t, err) {
+		var ids []string
+		for _, policy := range policies.EscalationPolicies {
+			ids = append(ids, policy.ID)
+		}
+		assert.Contains(t, ids, target.EscalationPolicyID)
+	}
+
+	_, err = mPD.UpdateEscalationPolicy(unknownID, target.UserID, "", "", []pagerduty.APIObject{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "UpdateEscalationPolicy of an unknown policy: %v", err)
+}
+
+func userIDs(users []pagerduty.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func scheduleIDs(schedules []pagerduty.Schedule) []string {
+	ids := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		ids = append(ids, schedule.ID)
+	}
+	return ids
+}
+
+func overrideIDs(overrides []pagerduty.Override) []string {
+	ids := make([]string, 0, len(overrides))
+	for _, override := range overrides {
+		ids = append(ids, override.ID)
+	}
+	return ids
+}
+
+func tagIDs(tags []*pagerduty.Tag) []string {
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	return ids
+}
+
+func incidentIDs(incidents []pagerduty.Incident) []string {
+	ids := make([]string, 0, len(incidents))
+	for _, incident := range incidents {
+		ids = append(ids, incident.ID)
+	}
+	return ids
+}
@@ -0,0 +1,167 @@
+package mPagerDuty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// CoverageReport is how well a schedule covers a window of time
+type CoverageReport struct {
+	ScheduleID string `json:"schedule_id"`
+	// TimeZone is the time zone of the schedule, which the times of the report are in
+	TimeZone string    `json:"time_zone"`
+	Since    time.Time `json:"since"`
+	Until    time.Time `json:"until"`
+
+	// Gaps lists when nobody is on call
+	Gaps []CoverageInterval `json:"gaps"`
+	// OverrideOnly lists when only overrides put somebody on call, so the layers alone would leave a gap
+	OverrideOnly []CoverageInterval `json:"override_only"`
+	// DeactivatedUsers lists the shifts of users PagerDuty no longer knows, which leave nobody to page either
+	DeactivatedUsers []RenderedEntry `json:"deactivated_users"`
+}
+
+// CoverageInterval is a stretch of time
+type CoverageInterval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Covered reports whether somebody who can be paged is on call throughout the window without relying on overrides
+func (r CoverageReport) Covered() bool {
+	return len(r.Gaps) == 0 && len(r.OverrideOnly) == 0 && len(r.DeactivatedUsers) == 0
+}
+
+// AnalyzeCoverage reports, for every schedule specified by ID, when nobody is on call between since and until,
+// when only overrides put somebody on call, and the shifts of users that were deactivated. It works with any
+// IMPagerDuty, rendering the schedules with GetRenderedSchedule and looking up their users with GetUserByID
+func AnalyzeCoverage(ctx context.Context, pd IMPagerDuty, scheduleIDs []string, since, until time.Time) ([]CoverageReport, error) {
+	if len(scheduleIDs) == 0 {
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleIDs' must be specified", ErrInvalidArgument)
+	}
+	for _, id := range scheduleIDs {
+		if strings.TrimSpace(id) == "" {
+			return nil, fmt.Errorf("%w: could not analyze coverage because the passed array had empty string(s)", ErrInvalidArgument)
+		}
+	}
+
+	// whether each user is still known, shared by the schedules that put the same users on call
+	deactivated := map[string]bool{}
+	reports := make([]CoverageReport, 0, len(scheduleIDs))
+	for _, id := range scheduleIDs {
+		rendered, err := pd.GetRenderedScheduleWithContext(ctx, id, since, until, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze the coverage of schedule '%s': %w", id, err)
+		}
+		report, err := analyzeRenderedSchedule(ctx, pd, rendered, deactivated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze the coverage of schedule '%s': %w", id, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// analyzeRenderedSchedule builds the CoverageReport of rendered, looking up the users deactivated does not know yet
+func analyzeRenderedSchedule(ctx context.Context, pd IMPagerDuty, rendered *RenderedSchedule, deactivated map[string]bool) (CoverageReport, error) {
+	report := CoverageReport{
+		ScheduleID:       rendered.ScheduleID,
+		TimeZone:         rendered.TimeZone,
+		Since:            rendered.Since,
+		Until:            rendered.Until,
+		Gaps:             []CoverageInterval{},
+		OverrideOnly:     []CoverageInterval{},
+		DeactivatedUsers: []RenderedEntry{},
+	}
+
+	final := entryIntervals(rendered.Final)
+	var layers []interval
+	for _, layer := range rendered.Layers {
+		layers = append(layers, entryIntervals(layer.Entries)...)
+	}
+	for _, gap := range subtractIntervals([]interval{{start: rendered.Since, end: rendered.Until}}, final) {
+		report.Gaps = append(report.Gaps, CoverageInterval{Start: gap.start, End: gap.end})
+	}
+	for _, overridden := range subtractIntervals(final, mergeIntervals(layers)) {
+		report.OverrideOnly = append(report.OverrideOnly, CoverageInterval{Start: overridden.start, End: overridden.end})
+	}
+
+	for _, entry := range rendered.Final {
+		gone, ok := deactivated[entry.UserID]
+		if !ok {
+			_, err := pd.GetUserByIDWithContext(ctx, entry.UserID, pagerduty.GetUserOptions{})
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return CoverageReport{}, err
+			}
+			gone = err != nil
+			deactivated[entry.UserID] = gone
+		}
+		if gone {
+			report.DeactivatedUsers = append(report.DeactivatedUsers, entry)
+		}
+	}
+	return report, nil
+}
+
+// interval is a stretch of time, such as the one the restrictions of a layer allow shifts in
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// entryIntervals returns the sorted, merged intervals entries cover
+func entryIntervals(entries []RenderedEntry) []interval {
+	intervals := make([]interval, 0, len(entries))
+	for _, entry := range entries {
+		intervals = append(intervals, interval{start: entry.Start, end: entry.End})
+	}
+	return mergeIntervals(intervals)
+}
+
+// mergeIntervals returns intervals sorted, with the ones that overlap or touch merged
+func mergeIntervals(intervals []interval) []interval {
+	sorted := append([]interval(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].start.Before(sorted[j].start) })
+	var merged []interval
+	for _, next := range sorted {
+		if last := len(merged) - 1; last >= 0 && !next.start.After(merged[last].end) {
+			if next.end.After(merged[last].end) {
+				merged[last].end = next.end
+			}
+			continue
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// subtractIntervals returns the parts of the sorted, merged intervals from that none of the sorted, merged intervals in remove cover
+func subtractIntervals(from, remove []interval) []interval {
+	var left []interval
+	r := 0
+	for _, current := range from {
+		// the intervals of remove that end before current starts cannot cut any later interval either
+		for r < len(remove) && !remove[r].end.After(current.start) {
+			r++
+		}
+		start := current.start
+		for k := r; k < len(remove) && remove[k].start.Before(current.end); k++ {
+			if remove[k].start.After(start) {
+				left = append(left, interval{start: start, end: remove[k].start})
+			}
+			if remove[k].end.After(start) {
+				start = remove[k].end
+			}
+		}
+		if current.end.After(start) {
+			left = append(left, interval{start: start, end: current.end})
+		}
+	}
+	return left
+}
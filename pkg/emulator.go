@@ -0,0 +1,436 @@
+package mPagerDuty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+const (
+	// emulatorDefaultLimit and emulatorMaxLimit are PagerDuty's default and largest page sizes
+	emulatorDefaultLimit = 25
+	emulatorMaxLimit     = 100
+)
+
+// Emulator is a local PagerDuty REST API serving the endpoints this package uses from memory,
+// so that the actual client, its pagination and its response decoding can be tested offline:
+//
+//	emulator, err := mPagerDuty.NewEmulator(nil)
+//	defer emulator.Close()
+//	mPD, err := mPagerDuty.GetMPagerDutyClient("token", mPagerDuty.WithMode(mPagerDuty.ModeEmulator), mPagerDuty.WithAPIEndpoint(emulator.URL))
+//
+// It keeps its resources like a FakePDClient does, pages lists by offset and limit,
+// and answers errors with PagerDuty's JSON error objects
+type Emulator struct {
+	// URL is the base URL of the emulator, to be passed to WithAPIEndpoint
+	URL string
+
+	server *http.Server
+	store  *fakeStore
+}
+
+// NewEmulator starts an emulator whose resources are those of fixtures, or of DefaultFixtures when fixtures is nil
+func NewEmulator(fixtures *Fixtures) (*Emulator, error) {
+	if fixtures == nil {
+		fixtures = DefaultFixtures()
+	}
+	if err := fixtures.Validate(); err != nil {
+		return nil, err
+	}
+
+	// a plain listener and server rather than net/http/httptest, which would register its flags in every binary
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		if listener, err = net.Listen("tcp6", "[::1]:0"); err != nil {
+			return nil, fmt.Errorf("failed to start the emulator: %w", err)
+		}
+	}
+	emulator := &Emulator{store: newFakeStore(fixtures)}
+	emulator.server = &http.Server{Handler: emulator}
+	emulator.URL = "http://" + listener.Addr().String()
+	go func() {
+		_ = emulator.server.Serve(listener)
+	}()
+	return emulator, nil
+}
+
+// SetClock makes the emulator tell the time by clock, for the creation time of new incidents,
+// or by SystemClock when clock is nil
+func (e *Emulator) SetClock(clock Clock) {
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+	e.store.clock = orSystemClock(clock)
+}
+
+// Close shuts the emulator down, waiting for the requests it is answering
+func (e *Emulator) Close() {
+	_ = e.server.Shutdown(context.Background())
+}
+
+// ServeHTTP answers a PagerDuty REST API request
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Token token=") {
+		writeEmulatorError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	e.store.mu.Lock()
+	defer e.store.mu.Unlock()
+
+	// every other segment of the paths the API serves is an ID
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	route := make([]string, len(path))
+	for i, segment := range path {
+		route[i] = segment
+		if i%2 == 1 {
+			route[i] = "{id}"
+		}
+	}
+
+	switch r.Method + " " + strings.Join(route, "/") {
+	case "GET users":
+		e.listUsers(w, r)
+	case "GET users/{id}":
+		e.getUser(w, path[1])
+	case "GET schedules":
+		e.listSchedules(w, r)
+	case "POST schedules":
+		e.createSchedule(w, r)
+	case "GET schedules/{id}":
+		e.getSchedule(w, r, path[1])
+	case "PUT schedules/{id}":
+		e.updateSchedule(w, r, path[1])
+	case "DELETE schedules/{id}":
+		e.deleteSchedule(w, path[1])
+	case "GET schedules/{id}/overrides":
+		e.listOverrides(w, r, path[1])
+	case "POST schedules/{id}/overrides":
+		e.createOverride(w, r, path[1])
+	case "DELETE schedules/{id}/overrides/{id}":
+		e.removeOverride(w, path[1], path[3])
+	case "GET oncalls":
+		e.listOnCalls(w, r)
+	case "GET incidents":
+		e.listIncidents(w, r)
+	case "POST incidents":
+		e.createIncident(w, r)
+	case "GET incidents/{id}/log_entries":
+		e.listLogEntries(w, r, path[1])
+	case "GET escalation_policies/{id}":
+		e.getEscalationPolicy(w, path[1])
+	case "PUT escalation_policies/{id}":
+		e.updateEscalationPolicy(w, r, path[1])
+	case "GET tags":
+		e.listTags(w, r)
+	case "GET tags/{id}/escalation_policies":
+		e.listTaggedEscalationPolicies(w, r, path[1])
+	default:
+		writeEmulatorError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (e *Emulator) listUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	users := e.store.listUsers(query.Get("query"), query["team_ids[]"])
+	page, list, ok := paginate(w, r, users)
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListUsersResponse{APIListObject: list, Users: page})
+	}
+}
+
+func (e *Emulator) getUser(w http.ResponseWriter, id string) {
+	i, ok := e.store.findUser(id)
+	if !ok {
+		writeEmulatorError(w, http.StatusNotFound, "User Not Found")
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, map[string]pagerduty.User{"user": e.store.users[i]})
+}
+
+func (e *Emulator) listSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules := e.store.listSchedules(r.URL.Query().Get("query"))
+	page, list, ok := paginate(w, r, schedules)
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListSchedulesResponse{APIListObject: list, Schedules: page})
+	}
+}
+
+func (e *Emulator) getSchedule(w http.ResponseWriter, r *http.Request, id string) {
+	query := r.URL.Query()
+	schedule, err := e.store.getSchedule(id, pagerduty.GetScheduleOptions{
+		TimeZone: query.Get("time_zone"),
+		Since:    query.Get("since"),
+		Until:    query.Get("until"),
+	})
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, map[string]pagerduty.Schedule{"schedule": schedule})
+}
+
+func (e *Emulator) createSchedule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Schedule pagerduty.Schedule `json:"schedule"`
+	}
+	if !decodeEmulatorBody(w, r, &body) {
+		return
+	}
+
+	schedule, err := e.store.createSchedule(body.Schedule)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusCreated, map[string]pagerduty.Schedule{"schedule": schedule})
+}
+
+func (e *Emulator) updateSchedule(w http.ResponseWriter, r *http.Request, id string) {
+	var body struct {
+		Schedule pagerduty.Schedule `json:"schedule"`
+	}
+	if !decodeEmulatorBody(w, r, &body) {
+		return
+	}
+
+	schedule, err := e.store.updateSchedule(id, body.Schedule)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, map[string]pagerduty.Schedule{"schedule": schedule})
+}
+
+func (e *Emulator) deleteSchedule(w http.ResponseWriter, id string) {
+	if err := e.store.deleteSchedule(id); err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (e *Emulator) listOverrides(w http.ResponseWriter, r *http.Request, scheduleID string) {
+	query := r.URL.Query()
+	overrides, err := e.store.listOverrides(scheduleID, query.Get("since"), query.Get("until"), query.Get("overflow") == "true")
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, pagerduty.ListOverridesResponse{Overrides: overrides})
+}
+
+func (e *Emulator) createOverride(w http.ResponseWriter, r *http.Request, scheduleID string) {
+	var body struct {
+		Override pagerduty.Override `json:"override"`
+	}
+	if !decodeEmulatorBody(w, r, &body) {
+		return
+	}
+
+	override, err := e.store.createOverride(scheduleID, body.Override.User.ID, body.Override.Start, body.Override.End)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusCreated, map[string]pagerduty.Override{"override": override})
+}
+
+func (e *Emulator) removeOverride(w http.ResponseWriter, scheduleID, overrideID string) {
+	if err := e.store.removeOverride(scheduleID, overrideID); err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (e *Emulator) listOnCalls(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	onCalls, err := e.store.listOnCalls(query["schedule_ids[]"], query["user_ids[]"], query["escalation_policy_ids[]"], query.Get("since"), query.Get("until"))
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	page, list, ok := paginate(w, r, onCalls)
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListOnCallsResponse{APIListObject: list, OnCalls: page})
+	}
+}
+
+func (e *Emulator) listIncidents(w http.ResponseWriter, r *http.Request) {
+	bounds := map[string]time.Time{}
+	for _, name := range []string{"since", "until"} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		bound, err := parseFakeTime(name, value)
+		if err != nil {
+			writeEmulatorStoreError(w, err)
+			return
+		}
+		bounds[name] = bound
+	}
+
+	page, list, ok := paginate(w, r, e.store.listIncidents(bounds["since"], bounds["until"]))
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListIncidentsResponse{APIListObject: list, Incidents: page})
+	}
+}
+
+func (e *Emulator) createIncident(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("From") == "" {
+		writeEmulatorError(w, http.StatusBadRequest, "You must specify a user's email address in the \"From\" header to perform this action")
+		return
+	}
+	var body struct {
+		Incident pagerduty.CreateIncidentOptions `json:"incident"`
+	}
+	if !decodeEmulatorBody(w, r, &body) {
+		return
+	}
+
+	options := body.Incident
+	if strings.TrimSpace(options.Title) == "" || options.Service == nil {
+		writeEmulatorError(w, http.StatusBadRequest, "Incident must have a title and a service")
+		return
+	}
+	var escalationPolicyID string
+	if options.EscalationPolicy != nil {
+		escalationPolicyID = options.EscalationPolicy.ID
+	}
+
+	incident := e.store.createIncident(options.Title, options.Service.ID, options.Urgency, escalationPolicyID)
+	writeEmulatorJSON(w, http.StatusCreated, map[string]pagerduty.Incident{"incident": incident})
+}
+
+func (e *Emulator) listLogEntries(w http.ResponseWriter, r *http.Request, incidentID string) {
+	logEntries, err := e.store.listLogEntries(incidentID)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	page, list, ok := paginate(w, r, logEntries)
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListIncidentLogEntriesResponse{APIListObject: list, LogEntries: page})
+	}
+}
+
+func (e *Emulator) getEscalationPolicy(w http.ResponseWriter, id string) {
+	i, ok := e.store.findEscalationPolicy(id)
+	if !ok {
+		writeEmulatorError(w, http.StatusNotFound, "Escalation Policy Not Found")
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, map[string]pagerduty.EscalationPolicy{"escalation_policy": e.store.escalationPolicies[i]})
+}
+
+func (e *Emulator) updateEscalationPolicy(w http.ResponseWriter, r *http.Request, id string) {
+	var body struct {
+		EscalationPolicy pagerduty.EscalationPolicy `json:"escalation_policy"`
+	}
+	if !decodeEmulatorBody(w, r, &body) {
+		return
+	}
+
+	policy, err := e.store.updateEscalationPolicy(id, body.EscalationPolicy)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	writeEmulatorJSON(w, http.StatusOK, map[string]pagerduty.EscalationPolicy{"escalation_policy": policy})
+}
+
+func (e *Emulator) listTags(w http.ResponseWriter, r *http.Request) {
+	page, list, ok := paginate(w, r, e.store.listTags(r.URL.Query().Get("query")))
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListTagResponse{APIListObject: list, Tags: page})
+	}
+}
+
+func (e *Emulator) listTaggedEscalationPolicies(w http.ResponseWriter, r *http.Request, tagID string) {
+	escalationPolicies, err := e.store.listTaggedEscalationPolicies(tagID)
+	if err != nil {
+		writeEmulatorStoreError(w, err)
+		return
+	}
+	page, list, ok := paginate(w, r, escalationPolicies)
+	if ok {
+		writeEmulatorJSON(w, http.StatusOK, pagerduty.ListEPResponse{APIListObject: list, EscalationPolicies: page})
+	}
+}
+
+// paginate cuts the page the offset and limit query parameters ask for out of items,
+// answering with a bad request itself when they are not numbers
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T) ([]T, pagerduty.APIListObject, bool) {
+	query := r.URL.Query()
+	offset, limit := uint64(0), uint64(emulatorDefaultLimit)
+	var err error
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.ParseUint(value, 10, 32); err != nil {
+			writeEmulatorError(w, http.StatusBadRequest, fmt.Sprintf("Invalid offset: %s", value))
+			return nil, pagerduty.APIListObject{}, false
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.ParseUint(value, 10, 32); err != nil || limit == 0 {
+			writeEmulatorError(w, http.StatusBadRequest, fmt.Sprintf("Invalid limit: %s", value))
+			return nil, pagerduty.APIListObject{}, false
+		}
+	}
+	if limit > emulatorMaxLimit {
+		limit = emulatorMaxLimit
+	}
+
+	total := uint64(len(items))
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	list := pagerduty.APIListObject{Offset: uint(offset), Limit: uint(limit), More: end < total}
+	if query.Get("total") == "true" {
+		list.Total = uint(total)
+	}
+	return items[start:end], list, true
+}
+
+func decodeEmulatorBody(w http.ResponseWriter, r *http.Request, body interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeEmulatorError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeEmulatorJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeEmulatorError answers with an error object like PagerDuty's
+func writeEmulatorError(w http.ResponseWriter, statusCode int, message string) {
+	writeEmulatorJSON(w, statusCode, map[string]pagerduty.APIErrorObject{"error": {Message: message}})
+}
+
+// writeEmulatorStoreError answers with the response the error of a store method stands for
+func writeEmulatorStoreError(w http.ResponseWriter, err error) {
+	var apiErr *APIError
+	var pdErr pagerduty.APIError
+	if errors.As(err, &apiErr) && errors.As(err, &pdErr) && pdErr.APIError.Valid {
+		writeEmulatorError(w, apiErr.StatusCode, pdErr.APIError.ErrorObject.Message)
+		return
+	}
+	writeEmulatorError(w, http.StatusInternalServerError, err.Error())
+}
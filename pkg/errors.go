@@ -0,0 +1,91 @@
+package mPagerDuty
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// Sentinel errors that every IMPagerDuty implementation wraps, so callers can branch with errors.Is
+// instead of matching error strings
+var (
+	// ErrInvalidArgument is returned when a passed parameter fails validation or PagerDuty rejects a request as malformed
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrNotFound is returned when the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is returned when the authentication token is missing or invalid
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden is returned when the authentication token is not allowed to perform the request
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited is returned when PagerDuty kept throttling a request after all retries were used up
+	ErrRateLimited = errors.New("rate limited")
+	// ErrConflict is returned when the request conflicts with the current state of a resource
+	ErrConflict = errors.New("conflict")
+	// ErrTruncated is returned when a list holds more items than WithMaxItems allows collecting
+	ErrTruncated = errors.New("truncated")
+	// ErrAmbiguous is returned when a name matches several resources equally well, see AmbiguousNameError
+	ErrAmbiguous = errors.New("ambiguous")
+)
+
+// APIError is returned when PagerDuty answered a request with an error status
+//
+// errors.Is matches it against the sentinel for its status code, and errors.As
+// can still reach the underlying pagerduty.APIError
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Kind is the sentinel error the status code maps to, or nil for e.g. server errors
+	Kind error
+	// Err is the error returned by go-pagerduty
+	Err error
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+func (e *APIError) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// wrapAPIError classifies errors returned by go-pagerduty and leaves any other error untouched
+func wrapAPIError(err error) error {
+	var apiErr pagerduty.APIError
+	if err == nil || !errors.As(err, &apiErr) {
+		return err
+	}
+
+	var kind error
+	switch {
+	case apiErr.NotFound():
+		kind = ErrNotFound
+	case apiErr.RateLimited():
+		kind = ErrRateLimited
+	case apiErr.StatusCode == http.StatusBadRequest:
+		kind = ErrInvalidArgument
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		kind = ErrUnauthorized
+	case apiErr.StatusCode == http.StatusForbidden:
+		kind = ErrForbidden
+	case apiErr.StatusCode == http.StatusConflict:
+		kind = ErrConflict
+	}
+
+	return &APIError{StatusCode: apiErr.StatusCode, Kind: kind, Err: err}
+}
+
+// newAPIError builds the *APIError PagerDuty would have caused by answering with statusCode and message
+func newAPIError(statusCode int, message string) error {
+	return wrapAPIError(pagerduty.APIError{
+		StatusCode: statusCode,
+		APIError: pagerduty.NullAPIErrorObject{
+			Valid:       true,
+			ErrorObject: pagerduty.APIErrorObject{Message: message},
+		},
+	})
+}
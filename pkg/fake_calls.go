@@ -0,0 +1,235 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Call is an invocation of a method of a FakePDClient by the code under test.
+// Calls one method of the fake makes to another are not recorded
+type Call struct {
+	// Method is the name of the method, e.g. "CreateOverride". Methods and their WithContext variants share a name
+	Method string
+	// Args are the arguments of the call, without its context
+	Args []interface{}
+	// Results are the values the call returned, without its error
+	Results []interface{}
+	// Err is the error the call returned
+	Err error
+}
+
+func (call Call) String() string {
+	return fmt.Sprintf("%s(%s)", call.Method, summarizeArgs(call.Args))
+}
+
+// ArgumentMatcher matches an argument of a call in the assertions of a FakePDClient,
+// where it can stand in for arguments that are not compared with reflect.DeepEqual
+type ArgumentMatcher func(arg interface{}) bool
+
+// Anything matches any argument
+var Anything ArgumentMatcher = func(interface{}) bool { return true }
+
+// TestingT is the part of *testing.T the assertions of a FakePDClient report failures to
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// matches reports whether the call has the expected arguments, or whether it was made at all when there are none
+func (call Call) matches(expected []interface{}) bool {
+	if len(expected) == 0 {
+		return true
+	}
+	if len(expected) != len(call.Args) {
+		return false
+	}
+	for i, arg := range call.Args {
+		if matcher, ok := expected[i].(ArgumentMatcher); ok {
+			if !matcher(arg) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(expected[i], arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// callRecorder keeps the calls of a FakePDClient in the order they were made
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// fakeCall is the recorded call a method of a FakePDClient is answering, nil for calls that are not recorded
+type fakeCall struct {
+	recorder *callRecorder
+	index    int
+	// observed emits the events of the call to the hooks and tracer of the client, nil when it has none
+	observed *methodCall
+}
+
+func (r *callRecorder) start(method string, args []interface{}) *fakeCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+	return &fakeCall{recorder: r, index: len(r.calls) - 1}
+}
+
+// end records what the call returned, given pointers to its results
+func (c *fakeCall) end(err *error, results ...interface{}) {
+	if c == nil {
+		return
+	}
+	c.observed.end(err)
+
+	values := make([]interface{}, len(results))
+	for i, result := range results {
+		values[i] = reflect.ValueOf(result).Elem().Interface()
+	}
+
+	c.recorder.mu.Lock()
+	defer c.recorder.mu.Unlock()
+	c.recorder.calls[c.index].Results = values
+	c.recorder.calls[c.index].Err = *err
+}
+
+// fakeCallKey marks contexts of calls a FakePDClient is already answering
+type fakeCallKey struct{}
+
+// enter is called first by every method of the fake. It records calls made by the caller,
+// fails calls whose context is done, and injects the faults of calls made by the caller.
+// The returned call is nil for calls the fake makes to itself
+func (fakeClient *FakePDClient) enter(ctx context.Context, method string, args ...interface{}) (context.Context, *fakeCall, error) {
+	if ctx.Value(fakeCallKey{}) != nil {
+		return ctx, nil, ctx.Err()
+	}
+	call := fakeClient.calls.start(method, args)
+	ctx, call.observed = fakeClient.observer.startCall(ctx, method, args)
+	if err := ctx.Err(); err != nil {
+		return ctx, call, err
+	}
+	ctx = context.WithValue(ctx, fakeCallKey{}, method)
+
+	latency, err := fakeClient.faults.inject(method)
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx, call, ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return ctx, call, err
+}
+
+// Calls returns the calls made so far, in order
+func (fakeClient *FakePDClient) Calls() []Call {
+	fakeClient.calls.mu.Lock()
+	defer fakeClient.calls.mu.Unlock()
+	return append([]Call(nil), fakeClient.calls.calls...)
+}
+
+// CallsTo returns the calls of method made so far, in order
+func (fakeClient *FakePDClient) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range fakeClient.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls made so far
+func (fakeClient *FakePDClient) ResetCalls() {
+	fakeClient.calls.mu.Lock()
+	defer fakeClient.calls.mu.Unlock()
+	fakeClient.calls.calls = nil
+}
+
+// AssertCalled asserts that method was called with args, or at all when no args are given
+func (fakeClient *FakePDClient) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	calls := fakeClient.CallsTo(method)
+	for _, call := range calls {
+		if call.matches(args) {
+			return true
+		}
+	}
+
+	if len(args) == 0 {
+		t.Errorf("expected %s to be called, but it was not", method)
+	} else {
+		t.Errorf("expected %s to be called, but it was not; calls of %s:%s", Call{Method: method, Args: args}, method, listCalls(calls))
+	}
+	return false
+}
+
+// AssertNotCalled asserts that method was never called with args, or never at all when no args are given
+func (fakeClient *FakePDClient) AssertNotCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	var matching []Call
+	for _, call := range fakeClient.CallsTo(method) {
+		if call.matches(args) {
+			matching = append(matching, call)
+		}
+	}
+	if len(matching) == 0 {
+		return true
+	}
+
+	t.Errorf("expected %s not to be called, but it was:%s", method, listCalls(matching))
+	return false
+}
+
+// AssertNumberOfCalls asserts that method was called exactly expected times
+func (fakeClient *FakePDClient) AssertNumberOfCalls(t TestingT, method string, expected int) bool {
+	t.Helper()
+	calls := fakeClient.CallsTo(method)
+	if len(calls) == expected {
+		return true
+	}
+
+	t.Errorf("expected %s to be called %d times, but it was called %d times:%s", method, expected, len(calls), listCalls(calls))
+	return false
+}
+
+// AssertCallOrder asserts that the methods were called in the given order, other calls may come in between
+func (fakeClient *FakePDClient) AssertCallOrder(t TestingT, methods ...string) bool {
+	t.Helper()
+	calls := fakeClient.Calls()
+	next := 0
+	for _, call := range calls {
+		if next < len(methods) && call.Method == methods[next] {
+			next++
+		}
+	}
+	if next == len(methods) {
+		return true
+	}
+
+	t.Errorf("expected calls in the order %s, but %s was not called in turn; calls:%s",
+		strings.Join(methods, ", "), methods[next], listCalls(calls))
+	return false
+}
+
+func listCalls(calls []Call) string {
+	if len(calls) == 0 {
+		return " none"
+	}
+	var list strings.Builder
+	for _, call := range calls {
+		list.WriteString("\n\t")
+		list.WriteString(call.String())
+	}
+	return list.String()
+}
@@ -1,19 +1,31 @@
 package mPagerDuty
 
-import "github.com/PagerDuty/go-pagerduty"
+import (
+	"time"
+
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// fakedTime parses the RFC3339 times of the faked data
+func fakedTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
 
 func getFakedOnCalls() *pagerduty.ListOnCallsResponse {
 	var response = &pagerduty.ListOnCallsResponse{
 		APIListObject: pagerduty.APIListObject{Limit: 100, Offset: 0, More: false, Total: 0},
 		OnCalls: []pagerduty.OnCall{
-			{
-				User:             getFakedUser(),
-				Schedule:         getFakedSchedule(),
-				EscalationPolicy: getFakedEscalationPolicy(),
-				EscalationLevel:  1,
-				Start:            "2021-07-21T14:54:39Z",
-				End:              "2022-12-30T14:04:11Z",
-			},
+			builder.OnCall(getFakedUser()).
+				Schedule(getFakedSchedule()).
+				EscalationPolicy(getFakedEscalationPolicy()).
+				Between(fakedTime("2021-07-21T14:54:39Z"), fakedTime("2022-12-30T14:04:11Z")).
+				Build(),
 		},
 	}
 
@@ -21,65 +33,56 @@ func getFakedOnCalls() *pagerduty.ListOnCallsResponse {
 }
 
 func getFakedUser() pagerduty.User {
-	user := pagerduty.User{
-		APIObject: pagerduty.APIObject{
-			ID:      "PJ6XOVE",
-			Self:    "https://api.pagerduty.com/users/PJ6XOVE",
-			HTMLURL: "https://twitchoncall.pagerduty.com/users/PJ6XOVE",
-		},
-		Name:     "Timur Kalandarov",
-		Email:    "tikaland@justin.tv",
-		Timezone: "America/New_York",
-		Role:     "user",
-		Teams: []pagerduty.Team{
-			{
-				APIObject: pagerduty.APIObject{
-					ID:      "P83EOFI",
-					Type:    "team_reference",
-					Summary: "GSOC",
-				},
-			},
-		},
-	}
-	return user
+	return builder.User(existingUserID).
+		Name(existingUserName).
+		Email("tikaland@justin.tv").
+		TimeZone("America/New_York").
+		HTMLURL("https://twitchoncall.pagerduty.com/users/"+existingUserID).
+		Team("P83EOFI", "GSOC").
+		Build()
 }
 
 func getFakedSchedule() pagerduty.Schedule {
-	schedule := pagerduty.Schedule{
-		APIObject: pagerduty.APIObject{
-			ID: "PUHMCXV",
-		},
-		Name: "",
-	}
-	return schedule
+	return builder.Schedule(existingScheduleID).
+		Name(existingScheduleName).
+		TimeZone("America/Denver").
+		Build()
 }
 
 func getFakedEscalationPolicy() pagerduty.EscalationPolicy {
-	policy := pagerduty.EscalationPolicy{}
-	return policy
+	return getFakedEscalationPolicies()[0]
+}
+
+func getFakedEscalationPolicies() []pagerduty.EscalationPolicy {
+	return []pagerduty.EscalationPolicy{
+		builder.EscalationPolicy("PP2PMMD").Name("Ad Server Escalation").
+			Rule(30, builder.ScheduleReference(existingScheduleID)).
+			Build(),
+		builder.EscalationPolicy("PKR3E6F").Name("App code validation").Build(),
+		builder.EscalationPolicy("PGPQHZF").Name("Browser Grid").Build(),
+		builder.EscalationPolicy("P23N6LT").Name("INCY DEV Escalation Policy").Build(),
+	}
+}
+
+// getFakedTaggedEscalationPolicies maps tag IDs to the IDs of the escalation policies they are assigned to
+func getFakedTaggedEscalationPolicies() map[string][]string {
+	return map[string][]string{
+		"P74RRGF": {"PP2PMMD", "PKR3E6F", "PGPQHZF"},
+	}
 }
 
 func getFakedOverridesList() pagerduty.ListOverridesResponse {
 	overrides := pagerduty.ListOverridesResponse{
 		Overrides: []pagerduty.Override{
-			{
-				ID:    "Q3WU06FHSCYOHG",
-				Start: "2022-08-31T14:00:00-06:00",
-				End:   "2022-09-01T00:00:00-06:00",
-				User:  getFakedUser().APIObject,
-			},
-			{
-				ID:    "Q1NF06I8X9HJAK",
-				Start: "2022-09-01T14:00:00-06:00",
-				End:   "2022-09-02T00:00:00-06:00",
-				User:  getFakedUser().APIObject,
-			},
-			{
-				ID:    "Q3BMMAACX0LQDM",
-				Start: "2022-09-03T14:00:00-06:00",
-				End:   "2022-09-04T00:00:00-06:00",
-				User:  getFakedUser().APIObject,
-			},
+			builder.Override("Q3WU06FHSCYOHG").User(existingUserID).
+				Between(fakedTime("2022-08-31T14:00:00-06:00"), fakedTime("2022-09-01T00:00:00-06:00")).
+				Build(),
+			builder.Override("Q1NF06I8X9HJAK").User(existingUserID).
+				Between(fakedTime("2022-09-01T14:00:00-06:00"), fakedTime("2022-09-02T00:00:00-06:00")).
+				Build(),
+			builder.Override("Q3BMMAACX0LQDM").User(existingUserID).
+				Between(fakedTime("2022-09-03T14:00:00-06:00"), fakedTime("2022-09-04T00:00:00-06:00")).
+				Build(),
 		},
 	}
 	return overrides
@@ -110,35 +113,46 @@ func getFakedTags() pagerduty.ListTagResponse {
 }
 
 func getFakedIncidents() []pagerduty.Incident {
-	incidents := []pagerduty.Incident{
+	return []pagerduty.Incident{
+		builder.Incident("Q3XZW6AK6GZ3TZ").Number(2024046).
+			Title("Twilight Automation Test Failure").
+			CreatedAt(fakedTime("2022-09-06T03:00:15Z")).
+			Status("resolved").Urgency("low").
+			Build(),
+		builder.Incident("Q0FDXK79NN127I").Number(2024049).
+			Title("Input Errors > 1000 over 1M").
+			Description("cr01.sin04 tengige0/0/0/2/0 COLO:tm:cr01.bkk01:te0/0/0/1/0:US021-161:10G:::").
+			CreatedAt(fakedTime("2022-09-06T03:04:51Z")).
+			Status("resolved").Urgency("low").
+			Build(),
+		builder.Incident("Q0HV1FERSUO36A").Number(2024046).
+			Title("BatchGetCheckoutPrice primary p99 Latency > 0.8s").
+			CreatedAt(fakedTime("2022-09-06T03:18:04Z")).
+			Status("resolved").Urgency("low").
+			Build(),
+	}
+}
+
+func getFakedLogEntries() []pagerduty.LogEntry {
+	entries := []pagerduty.LogEntry{
 		{
-			IncidentNumber: 2024046,
-			Title:          "Twilight Automation Test Failure",
-			Description:    "Twilight Automation Test Failure",
-			CreatedAt:      "2022-09-06T03:00:15Z",
-			Status:         "resolved",
-			Urgency:        "low",
-			APIObject:      pagerduty.APIObject{ID: "Q3XZW6AK6GZ3TZ"},
+			CommonLogEntryField: pagerduty.CommonLogEntryField{
+				APIObject: pagerduty.APIObject{Type: "trigger_log_entry", Summary: "Triggered through the API."},
+				CreatedAt: "2022-09-06T03:00:15Z",
+			},
 		},
 		{
-			IncidentNumber: 2024049,
-			Title:          "Input Errors > 1000 over 1M",
-			Description:    "cr01.sin04 tengige0/0/0/2/0 COLO:tm:cr01.bkk01:te0/0/0/1/0:US021-161:10G:::",
-			CreatedAt:      "2022-09-06T03:04:51Z",
-			Status:         "resolved",
-			Urgency:        "low",
-			APIObject:      pagerduty.APIObject{ID: "Q0FDXK79NN127I"},
+			CommonLogEntryField: pagerduty.CommonLogEntryField{
+				APIObject: pagerduty.APIObject{Type: "assign_log_entry", Summary: "Assigned to Timur Kalandarov."},
+				CreatedAt: "2022-09-06T03:00:16Z",
+			},
 		},
 		{
-			IncidentNumber: 2024046,
-			Title:          "BatchGetCheckoutPrice primary p99 Latency > 0.8s",
-			Description:    "BatchGetCheckoutPrice primary p99 Latency > 0.8s",
-			CreatedAt:      "2022-09-06T03:18:04Z",
-			Status:         "resolved",
-			Urgency:        "low",
-			APIObject:      pagerduty.APIObject{ID: "Q0HV1FERSUO36A"},
+			CommonLogEntryField: pagerduty.CommonLogEntryField{
+				APIObject: pagerduty.APIObject{Type: "resolve_log_entry", Summary: "Resolved through the API."},
+				CreatedAt: "2022-09-06T03:10:00Z",
+			},
 		},
 	}
-
-	return incidents
+	return entries
 }
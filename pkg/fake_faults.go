@@ -0,0 +1,153 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Fault is a failure a FakePDClient can be told to answer calls with
+type Fault int
+
+const (
+	// FaultNone injects no error, so the rule only adds its latency
+	FaultNone Fault = iota
+	// FaultRateLimited fails the call like PagerDuty's HTTP 429, matching ErrRateLimited
+	FaultRateLimited
+	// FaultServerError fails the call like PagerDuty's HTTP 500
+	FaultServerError
+	// FaultTimeout fails the call with context.DeadlineExceeded, like a request that ran out of time
+	FaultTimeout
+	// FaultNotFound fails the call like PagerDuty's HTTP 404, matching ErrNotFound
+	FaultNotFound
+)
+
+func (f Fault) String() string {
+	switch f {
+	case FaultNone:
+		return "none"
+	case FaultRateLimited:
+		return "rate_limited"
+	case FaultServerError:
+		return "server_error"
+	case FaultTimeout:
+		return "timeout"
+	case FaultNotFound:
+		return "not_found"
+	}
+	return fmt.Sprintf("Fault(%d)", int(f))
+}
+
+func (f Fault) err() error {
+	switch f {
+	case FaultRateLimited:
+		return newAPIError(http.StatusTooManyRequests, "Rate Limit Exceeded")
+	case FaultServerError:
+		return newAPIError(http.StatusInternalServerError, "Internal Server Error")
+	case FaultTimeout:
+		return context.DeadlineExceeded
+	case FaultNotFound:
+		return newAPIError(http.StatusNotFound, "Not Found")
+	}
+	return nil
+}
+
+// FaultRule makes a FakePDClient fail or slow down some of the calls of a method
+//
+// A rule fires on its Nth matching call when Nth is set, with the given Probability when that is set,
+// and on every matching call otherwise. Only calls made by the caller count; calls one method of the
+// fake makes to another do not
+type FaultRule struct {
+	// Method is the name of the method the rule applies to, e.g. "GetOverrides"; empty applies it to every method.
+	// Methods and their WithContext variants share a name
+	Method string
+	// Fault is the error calls fail with when the rule fires
+	Fault Fault
+	// Latency is waited before a call the rule fires on returns, or until the call's context is done
+	Latency time.Duration
+	// Nth makes the rule fire only on the Nth matching call, counting from 1
+	Nth int
+	// Probability makes the rule fire on a matching call with the given probability between 0 and 1
+	Probability float64
+	// Times is the number of times the rule fires at most, 0 means no limit
+	Times int
+}
+
+// Faults are the fault rules of a FakePDClient. When several rules fire on a call, their latencies
+// add up and the call fails with the fault of the first of them that has one
+type Faults struct {
+	Rules []FaultRule
+	// Seed seeds the random numbers that decide whether rules with a Probability fire, so runs are repeatable
+	Seed int64
+}
+
+// WithFaults makes clients in ModeFake inject faults
+func WithFaults(faults Faults) ClientOption {
+	return func(o *clientOptions) {
+		o.faults = &faults
+	}
+}
+
+// faultInjector decides which calls of a FakePDClient fail according to its rules
+type faultInjector struct {
+	mu    sync.Mutex
+	rules []FaultRule
+	rand  *rand.Rand
+	// matched and fired count the calls each rule matched and fired on
+	matched []int
+	fired   []int
+}
+
+func (f *faultInjector) reset(faults Faults) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rules = append([]FaultRule(nil), faults.Rules...)
+	f.rand = rand.New(rand.NewSource(faults.Seed))
+	f.matched = make([]int, len(f.rules))
+	f.fired = make([]int, len(f.rules))
+}
+
+// inject returns how long a call of method is delayed and the error it fails with
+func (f *faultInjector) inject(method string) (time.Duration, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var latency time.Duration
+	var err error
+	for i, rule := range f.rules {
+		if rule.Method != "" && rule.Method != method {
+			continue
+		}
+		f.matched[i]++
+
+		if rule.Times > 0 && f.fired[i] >= rule.Times {
+			continue
+		}
+		switch {
+		case rule.Nth > 0:
+			if f.matched[i] != rule.Nth {
+				continue
+			}
+		case rule.Probability > 0:
+			if f.rand.Float64() >= rule.Probability {
+				continue
+			}
+		}
+		f.fired[i]++
+
+		latency += rule.Latency
+		if err == nil {
+			err = rule.Fault.err()
+		}
+	}
+	return latency, err
+}
+
+// SetFaults replaces the fault rules of the client and restarts counting calls
+func (fakeClient *FakePDClient) SetFaults(faults Faults) {
+	fakeClient.faults.reset(faults)
+}
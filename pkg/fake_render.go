@@ -0,0 +1,325 @@
+package mPagerDuty
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// shift is a stretch of time a layer or an override puts a user on call
+type shift struct {
+	start  time.Time
+	end    time.Time
+	userID string
+}
+
+// overlaps reports whether the shift overlaps the range between since and until,
+// where a range that is a single point in time overlaps the shift under way
+func (s shift) overlaps(since, until time.Time) bool {
+	return s.end.After(since) && (s.start.Before(until) || !s.start.After(since))
+}
+
+// renderSchedule returns the schedule at index i with the rendered entries of its layers, its overrides
+// and its final schedule between since and until, in location or in the time zone of the schedule when location is nil
+//
+// Like in PagerDuty, layers later in the list of layers take precedence over earlier ones,
+// and overrides take precedence over every layer
+func (s *fakeStore) renderSchedule(i int, since, until time.Time, location *time.Location) pagerduty.Schedule {
+	schedule := deepCopy(s.schedules[i])
+	if location == nil {
+		location = scheduleLocation(schedule)
+	}
+
+	layers, overrides := s.scheduleShifts(i, since, until)
+	for l := range schedule.ScheduleLayers {
+		schedule.ScheduleLayers[l].RenderedScheduleEntries = s.renderedScheduleEntries(layers[l], location)
+	}
+	schedule.OverrideSubschedule = pagerduty.ScheduleLayer{
+		Name:                    "Overrides",
+		RenderedScheduleEntries: s.renderedScheduleEntries(overrides, location),
+	}
+	schedule.FinalSchedule = pagerduty.ScheduleLayer{
+		Name:                    "Final Schedule",
+		RenderedScheduleEntries: s.renderedScheduleEntries(finalShifts(layers, overrides), location),
+	}
+	return schedule
+}
+
+// scheduleShifts returns the shifts of every layer and the overrides of the schedule at index i between since and until
+func (s *fakeStore) scheduleShifts(i int, since, until time.Time) (layers [][]shift, overrides []shift) {
+	schedule := s.schedules[i]
+	// restrictions are times of day in the time zone of the schedule
+	location := scheduleLocation(schedule)
+	layers = make([][]shift, len(schedule.ScheduleLayers))
+	for l, layer := range schedule.ScheduleLayers {
+		layers[l] = renderLayer(layer, since, until, location)
+	}
+	return layers, s.overrideShifts(schedule.ID, since, until)
+}
+
+// scheduleLocation returns the time zone of schedule, or UTC if it is unknown
+func scheduleLocation(schedule pagerduty.Schedule) *time.Location {
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// renderLayer returns the shifts the rotation of layer puts users on call for between since and until,
+// cut to the restrictions of the layer
+func renderLayer(layer pagerduty.ScheduleLayer, since, until time.Time, location *time.Location) []shift {
+	start, err := time.Parse(time.RFC3339, layer.Start)
+	if err != nil {
+		return nil
+	}
+	virtualStart, err := time.Parse(time.RFC3339, layer.RotationVirtualStart)
+	if err != nil {
+		return nil
+	}
+	from, to := since, until
+	if start.After(from) {
+		from = start
+	}
+	if layer.End != "" {
+		end, err := time.Parse(time.RFC3339, layer.End)
+		if err != nil {
+			return nil
+		}
+		if end.Before(to) {
+			to = end
+		}
+	}
+	turn := time.Duration(layer.RotationTurnLengthSeconds) * time.Second
+	if !to.After(from) || turn <= 0 || len(layer.Users) == 0 {
+		return nil
+	}
+
+	// the turn under way at from, counted from the virtual start
+	elapsed := from.Sub(virtualStart)
+	k := int64(elapsed / turn)
+	if elapsed%turn < 0 {
+		k--
+	}
+	users := int64(len(layer.Users))
+
+	var shifts []shift
+	for turnStart := virtualStart.Add(time.Duration(k) * turn); turnStart.Before(to); turnStart, k = turnStart.Add(turn), k+1 {
+		user := layer.Users[((k%users)+users)%users].User.ID
+		if cut, ok := cutShift(shift{start: turnStart, end: turnStart.Add(turn), userID: user}, from, to); ok {
+			shifts = append(shifts, cut)
+		}
+	}
+	if len(layer.Restrictions) == 0 {
+		return shifts
+	}
+
+	allowed := restrictionIntervals(layer.Restrictions, from, to, location)
+	var restricted []shift
+	for _, shift := range shifts {
+		for _, window := range allowed {
+			if cut, ok := cutShift(shift, window.start, window.end); ok {
+				restricted = append(restricted, cut)
+			}
+		}
+	}
+	return restricted
+}
+
+// cutShift returns the part of shift between from and to, if there is any
+func cutShift(s shift, from, to time.Time) (shift, bool) {
+	if s.start.Before(from) {
+		s.start = from
+	}
+	if s.end.After(to) {
+		s.end = to
+	}
+	return s, s.end.After(s.start)
+}
+
+// restrictionIntervals returns the sorted, merged intervals the daily and weekly restrictions allow between from and to
+func restrictionIntervals(restrictions []pagerduty.Restriction, from, to time.Time, location *time.Location) []interval {
+	var intervals []interval
+	for _, restriction := range restrictions {
+		timeOfDay, err := time.Parse("15:04:05", restriction.StartTimeOfDay)
+		if err != nil {
+			continue
+		}
+		duration := time.Duration(restriction.DurationSeconds) * time.Second
+
+		// a restriction that starts the week before from may still be under way
+		first := from.In(location).AddDate(0, 0, -7)
+		for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, location); day.Before(to); day = day.AddDate(0, 0, 1) {
+			// PagerDuty numbers the days of the week from 1 for Monday to 7 for Sunday
+			weekday := uint(day.Weekday())
+			if weekday == 0 {
+				weekday = 7
+			}
+			if restriction.Type == "weekly_restriction" && weekday != restriction.StartDayOfWeek {
+				continue
+			}
+			start := time.Date(day.Year(), day.Month(), day.Day(), timeOfDay.Hour(), timeOfDay.Minute(), timeOfDay.Second(), 0, location)
+			end := start.Add(duration)
+			if end.After(from) && start.Before(to) {
+				intervals = append(intervals, interval{start: start, end: end})
+			}
+		}
+	}
+
+	return mergeIntervals(intervals)
+}
+
+// overrideShifts returns the overrides of the schedule between since and until, in the order they were created
+func (s *fakeStore) overrideShifts(scheduleID string, since, until time.Time) []shift {
+	var shifts []shift
+	for _, override := range s.overrides[scheduleID] {
+		start, err := time.Parse(time.RFC3339, override.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, override.End)
+		if err != nil {
+			continue
+		}
+		if cut, ok := cutShift(shift{start: start, end: end, userID: override.User.ID}, since, until); ok {
+			shifts = append(shifts, cut)
+		}
+	}
+	return shifts
+}
+
+// finalShifts combines the shifts of the layers and the overrides: overrides created later win over
+// earlier ones and over every layer, and later layers win over earlier ones
+func finalShifts(layers [][]shift, overrides []shift) []shift {
+	var boundaries []time.Time
+	for _, shifts := range layers {
+		for _, shift := range shifts {
+			boundaries = append(boundaries, shift.start, shift.end)
+		}
+	}
+	for _, shift := range overrides {
+		boundaries = append(boundaries, shift.start, shift.end)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+
+	var final []shift
+	for b := 0; b+1 < len(boundaries); b++ {
+		start, end := boundaries[b], boundaries[b+1]
+		if !end.After(start) {
+			continue
+		}
+		userID, ok := onCallAt(layers, overrides, start)
+		if !ok {
+			continue
+		}
+		if last := len(final) - 1; last >= 0 && final[last].userID == userID && final[last].end.Equal(start) {
+			final[last].end = end
+			continue
+		}
+		final = append(final, shift{start: start, end: end, userID: userID})
+	}
+	return final
+}
+
+// onCallAt returns the user the overrides or the layers put on call at t
+func onCallAt(layers [][]shift, overrides []shift, t time.Time) (string, bool) {
+	for o := len(overrides) - 1; o >= 0; o-- {
+		if !overrides[o].start.After(t) && overrides[o].end.After(t) {
+			return overrides[o].userID, true
+		}
+	}
+	for l := len(layers) - 1; l >= 0; l-- {
+		// the shifts of a layer are sorted and do not overlap
+		shifts := layers[l]
+		j := sort.Search(len(shifts), func(j int) bool { return shifts[j].end.After(t) })
+		if j < len(shifts) && !shifts[j].start.After(t) {
+			return shifts[j].userID, true
+		}
+	}
+	return "", false
+}
+
+// renderedScheduleEntries returns shifts the way PagerDuty renders them, in location
+func (s *fakeStore) renderedScheduleEntries(shifts []shift, location *time.Location) []pagerduty.RenderedScheduleEntry {
+	entries := make([]pagerduty.RenderedScheduleEntry, 0, len(shifts))
+	for _, shift := range shifts {
+		user := pagerduty.APIObject{ID: shift.userID, Type: "user_reference"}
+		if i, ok := s.findUser(shift.userID); ok {
+			user.Summary = s.users[i].Name
+		}
+		entries = append(entries, pagerduty.RenderedScheduleEntry{
+			Start: shift.start.In(location).Format(time.RFC3339),
+			End:   shift.end.In(location).Format(time.RFC3339),
+			User:  user,
+		})
+	}
+	return entries
+}
+
+// onCallLookaround is how far before and after the requested range the faked on-calls are looked for,
+// so that shifts under way keep their start and end
+const onCallLookaround = 14 * 24 * time.Hour
+
+// computeOnCalls returns the on-calls the escalation rules put in place between since and until: one for every
+// shift of the final schedule a rule targets, and a permanent one for every user a rule targets directly.
+// Like in PagerDuty, on-calls of users targeted directly are left out when filtering by schedule
+func (s *fakeStore) computeOnCalls(scheduleIDs, escalationPolicyIDs []string, since, until time.Time) []pagerduty.OnCall {
+	onCalls := []pagerduty.OnCall{}
+	for _, policy := range s.escalationPolicies {
+		if len(escalationPolicyIDs) > 0 && !containsString(escalationPolicyIDs, policy.ID) {
+			continue
+		}
+		onCall := pagerduty.OnCall{
+			EscalationPolicy: pagerduty.EscalationPolicy{
+				APIObject: pagerduty.APIObject{ID: policy.ID, Type: "escalation_policy_reference", Summary: policy.Name},
+			},
+		}
+		for level, rule := range policy.EscalationRules {
+			onCall.EscalationLevel = uint(level + 1)
+			for _, target := range rule.Targets {
+				switch {
+				case strings.HasPrefix(target.Type, "user"):
+					if len(scheduleIDs) > 0 {
+						continue
+					}
+					onCall.User = s.userReference(target.ID)
+					onCall.Schedule = pagerduty.Schedule{}
+					onCall.Start, onCall.End = "", ""
+					onCalls = append(onCalls, onCall)
+				case strings.HasPrefix(target.Type, "schedule"):
+					if len(scheduleIDs) > 0 && !containsString(scheduleIDs, target.ID) {
+						continue
+					}
+					i, ok := s.findSchedule(target.ID)
+					if !ok {
+						continue
+					}
+					onCall.Schedule = pagerduty.Schedule{
+						APIObject: pagerduty.APIObject{ID: target.ID, Type: "schedule_reference", Summary: s.schedules[i].Name},
+					}
+					for _, shift := range finalShifts(s.scheduleShifts(i, since.Add(-onCallLookaround), until.Add(onCallLookaround))) {
+						if !shift.overlaps(since, until) {
+							continue
+						}
+						onCall.User = s.userReference(shift.userID)
+						onCall.Start = shift.start.UTC().Format(time.RFC3339)
+						onCall.End = shift.end.UTC().Format(time.RFC3339)
+						onCalls = append(onCalls, onCall)
+					}
+				}
+			}
+		}
+	}
+	return onCalls
+}
+
+// userReference returns the reference to the user, with the name of the user as its summary
+func (s *fakeStore) userReference(id string) pagerduty.User {
+	user := pagerduty.User{APIObject: pagerduty.APIObject{ID: id, Type: "user_reference"}}
+	if i, ok := s.findUser(id); ok {
+		user.Summary = s.users[i].Name
+	}
+	return user
+}
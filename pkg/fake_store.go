@@ -0,0 +1,563 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// fakeStore keeps the resources of a FakePDClient in memory so that what one call
+// creates, updates or deletes is reflected by the calls that follow it
+type fakeStore struct {
+	mu     sync.Mutex
+	nextID int
+	// clock stamps what the store creates
+	clock Clock
+
+	users              []pagerduty.User
+	schedules          []pagerduty.Schedule
+	overrides          map[string][]pagerduty.Override
+	onCalls            []pagerduty.OnCall
+	tags               []pagerduty.Tag
+	escalationPolicies []pagerduty.EscalationPolicy
+	// taggedEscalationPolicies lists the IDs of the escalation policies each tag is assigned to
+	taggedEscalationPolicies map[string][]string
+	incidents                []pagerduty.Incident
+	logEntries               map[string][]pagerduty.LogEntry
+}
+
+// newFakeStore returns a store holding deep copies of the resources of fixtures, so that neither changes the other.
+// Like schedules, every resource the store hands out is a deep copy too
+func newFakeStore(fixtures *Fixtures) *fakeStore {
+	return &fakeStore{
+		clock:                    SystemClock,
+		users:                    deepCopy(fixtures.Users),
+		schedules:                deepCopy(fixtures.Schedules),
+		overrides:                copyLists(fixtures.Overrides),
+		onCalls:                  deepCopy(fixtures.OnCalls),
+		tags:                     deepCopy(fixtures.Tags),
+		escalationPolicies:       deepCopy(fixtures.EscalationPolicies),
+		taggedEscalationPolicies: copyLists(fixtures.TaggedEscalationPolicies),
+		incidents:                deepCopy(fixtures.Incidents),
+		logEntries:               copyLists(fixtures.LogEntries),
+	}
+}
+
+// copyLists deep-copies every list of lists, returning an empty map rather than nil so that lists can be added
+func copyLists[T any](lists map[string][]T) map[string][]T {
+	copied := make(map[string][]T, len(lists))
+	for key, values := range lists {
+		copied[key] = deepCopy(values)
+	}
+	return copied
+}
+
+// newID returns an ID that no seeded resource uses, shaped like PagerDuty's
+func (s *fakeStore) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%sFAKE%03d", prefix, s.nextID)
+}
+
+func (s *fakeStore) findUser(id string) (int, bool) {
+	for i := range s.users {
+		if s.users[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *fakeStore) findSchedule(id string) (int, bool) {
+	for i := range s.schedules {
+		if s.schedules[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *fakeStore) findTag(id string) (int, bool) {
+	for i := range s.tags {
+		if s.tags[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *fakeStore) findEscalationPolicy(id string) (int, bool) {
+	for i := range s.escalationPolicies {
+		if s.escalationPolicies[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (s *fakeStore) findIncident(id string) (int, bool) {
+	for i := range s.incidents {
+		if s.incidents[i].ID == id {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func notFoundError(resource string) error {
+	return newAPIError(http.StatusNotFound, resource+" Not Found")
+}
+
+// containsFold reports whether substr is within s, ignoring case like PagerDuty's query parameters
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFakeTime parses the timestamps PagerDuty accepts, answering like PagerDuty
+// with a bad request when it cannot
+func parseFakeTime(name, value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, newAPIError(http.StatusBadRequest, fmt.Sprintf("%s is not a valid ISO 8601 date: %s", name, value))
+	}
+	return parsed, nil
+}
+
+// createdSince reports whether incident was created at or after since
+func createdSince(incident pagerduty.Incident, since time.Time) bool {
+	created, err := time.Parse(time.RFC3339, incident.CreatedAt)
+	return err == nil && !created.Before(since)
+}
+
+// The methods below are shared by FakePDClient and Emulator, so both answer alike.
+// Callers hold s.mu, and errors are the ones PagerDuty would have answered with
+
+// listOnCalls returns the stored on-calls, unless since or until is set. The on-calls between since and until,
+// which default to each other, are then computed from the escalation policies and the schedules they target
+func (s *fakeStore) listOnCalls(scheduleIDs, userIDs, escalationPolicyIDs []string, since, until string) ([]pagerduty.OnCall, error) {
+	candidates := s.onCalls
+	if since != "" || until != "" {
+		if since == "" {
+			since = until
+		}
+		if until == "" {
+			until = since
+		}
+		from, err := parseFakeTime("since", since)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseFakeTime("until", until)
+		if err != nil {
+			return nil, err
+		}
+		if to.Before(from) {
+			return nil, newAPIError(http.StatusBadRequest, "until must be after since")
+		}
+		candidates = s.computeOnCalls(scheduleIDs, escalationPolicyIDs, from, to)
+	}
+
+	onCalls := []pagerduty.OnCall{}
+	for _, onCall := range candidates {
+		if len(scheduleIDs) > 0 && !containsString(scheduleIDs, onCall.Schedule.ID) {
+			continue
+		}
+		if len(userIDs) > 0 && !containsString(userIDs, onCall.User.ID) {
+			continue
+		}
+		if len(escalationPolicyIDs) > 0 && !containsString(escalationPolicyIDs, onCall.EscalationPolicy.ID) {
+			continue
+		}
+		onCalls = append(onCalls, deepCopy(onCall))
+	}
+	return onCalls, nil
+}
+
+// listUsers returns the users whose name or email contain query and who belong to one of teamIDs, when these are set
+func (s *fakeStore) listUsers(query string, teamIDs []string) []pagerduty.User {
+	users := []pagerduty.User{}
+	for _, user := range s.users {
+		if query != "" && !containsFold(user.Name, query) && !containsFold(user.Email, query) {
+			continue
+		}
+		if len(teamIDs) > 0 && !userInTeams(user, teamIDs) {
+			continue
+		}
+		users = append(users, deepCopy(user))
+	}
+	return users
+}
+
+func userInTeams(user pagerduty.User, teamIDs []string) bool {
+	for _, team := range user.Teams {
+		if containsString(teamIDs, team.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeStore) listSchedules(query string) []pagerduty.Schedule {
+	schedules := []pagerduty.Schedule{}
+	for _, schedule := range s.schedules {
+		if query == "" || containsFold(schedule.Name, query) {
+			schedules = append(schedules, deepCopy(schedule))
+		}
+	}
+	return schedules
+}
+
+// getSchedule returns the schedule, rendered between options.Since and options.Until
+// in options.TimeZone when both are set, like PagerDuty renders the schedules it returns
+func (s *fakeStore) getSchedule(id string, options pagerduty.GetScheduleOptions) (pagerduty.Schedule, error) {
+	i, ok := s.findSchedule(id)
+	if !ok {
+		return pagerduty.Schedule{}, notFoundError("Schedule")
+	}
+	if options.Since == "" || options.Until == "" {
+		return deepCopy(s.schedules[i]), nil
+	}
+
+	since, err := parseFakeTime("since", options.Since)
+	if err != nil {
+		return pagerduty.Schedule{}, err
+	}
+	until, err := parseFakeTime("until", options.Until)
+	if err != nil {
+		return pagerduty.Schedule{}, err
+	}
+	var location *time.Location
+	if options.TimeZone != "" {
+		if location, err = time.LoadLocation(options.TimeZone); err != nil {
+			return pagerduty.Schedule{}, newAPIError(http.StatusBadRequest, fmt.Sprintf("Time zone is invalid: %s", options.TimeZone))
+		}
+	}
+	return s.renderSchedule(i, since, until, location), nil
+}
+
+// checkSchedule answers like PagerDuty when the schedule cannot be stored: its time zone has to be known,
+// and every layer needs a start, the start and length of its turns and known users to take them
+func (s *fakeStore) checkSchedule(schedule pagerduty.Schedule) error {
+	if _, err := time.LoadLocation(schedule.TimeZone); schedule.TimeZone == "" || err != nil {
+		return newAPIError(http.StatusBadRequest, fmt.Sprintf("Time zone is invalid: %s", schedule.TimeZone))
+	}
+	if len(schedule.ScheduleLayers) < 1 {
+		return newAPIError(http.StatusBadRequest, "Schedule must have at least one layer")
+	}
+	for _, layer := range schedule.ScheduleLayers {
+		if _, err := parseFakeTime("start", layer.Start); err != nil {
+			return err
+		}
+		if _, err := parseFakeTime("rotation_virtual_start", layer.RotationVirtualStart); err != nil {
+			return err
+		}
+		if layer.End != "" {
+			if _, err := parseFakeTime("end", layer.End); err != nil {
+				return err
+			}
+		}
+		if layer.RotationTurnLengthSeconds == 0 {
+			return newAPIError(http.StatusBadRequest, "Rotation turn length must be positive")
+		}
+		if len(layer.Users) < 1 {
+			return newAPIError(http.StatusBadRequest, "Schedule layer must have at least one user")
+		}
+		for _, user := range layer.Users {
+			if _, ok := s.findUser(user.User.ID); !ok {
+				return notFoundError("User")
+			}
+		}
+	}
+	return nil
+}
+
+// storeSchedule gives the layers of schedule that have none an ID, and lists the users
+// of its layers like PagerDuty does, before putting it at index i or appending it when i is negative
+func (s *fakeStore) storeSchedule(i int, schedule pagerduty.Schedule) pagerduty.Schedule {
+	// neither the caller's schedule nor the one returned share anything with the stored one
+	schedule = deepCopy(schedule)
+	schedule.Type = "schedule"
+	schedule.Summary = schedule.Name
+	schedule.Users = nil
+	listed := map[string]bool{}
+	for l := range schedule.ScheduleLayers {
+		layer := &schedule.ScheduleLayers[l]
+		if layer.ID == "" {
+			layer.ID = s.newID("P")
+		}
+		for _, user := range layer.Users {
+			if listed[user.User.ID] {
+				continue
+			}
+			listed[user.User.ID] = true
+			u, _ := s.findUser(user.User.ID)
+			schedule.Users = append(schedule.Users, pagerduty.APIObject{
+				ID:      user.User.ID,
+				Type:    "user_reference",
+				Summary: s.users[u].Name,
+			})
+		}
+	}
+
+	if i < 0 {
+		s.schedules = append(s.schedules, schedule)
+	} else {
+		s.schedules[i] = schedule
+	}
+	return deepCopy(schedule)
+}
+
+func (s *fakeStore) createSchedule(schedule pagerduty.Schedule) (pagerduty.Schedule, error) {
+	if err := s.checkSchedule(schedule); err != nil {
+		return pagerduty.Schedule{}, err
+	}
+	schedule.ID = s.newID("P")
+	schedule.EscalationPolicies = nil
+	return s.storeSchedule(-1, schedule), nil
+}
+
+// updateSchedule replaces the schedule with update, keeping its ID and the escalation policies that use it
+func (s *fakeStore) updateSchedule(id string, update pagerduty.Schedule) (pagerduty.Schedule, error) {
+	i, ok := s.findSchedule(id)
+	if !ok {
+		return pagerduty.Schedule{}, notFoundError("Schedule")
+	}
+	if err := s.checkSchedule(update); err != nil {
+		return pagerduty.Schedule{}, err
+	}
+	update.ID = id
+	update.EscalationPolicies = s.schedules[i].EscalationPolicies
+	return s.storeSchedule(i, update), nil
+}
+
+// deleteSchedule deletes the schedule along with its overrides and on-calls. Like PagerDuty,
+// it refuses to delete schedules that escalation policies escalate to
+func (s *fakeStore) deleteSchedule(id string) error {
+	i, ok := s.findSchedule(id)
+	if !ok {
+		return notFoundError("Schedule")
+	}
+	for _, policy := range s.escalationPolicies {
+		for _, rule := range policy.EscalationRules {
+			for _, target := range rule.Targets {
+				if target.ID == id && strings.HasPrefix(target.Type, "schedule") {
+					return newAPIError(http.StatusBadRequest, fmt.Sprintf("Schedule cannot be deleted while the escalation policy %s uses it", policy.Name))
+				}
+			}
+		}
+	}
+
+	s.schedules = append(s.schedules[:i:i], s.schedules[i+1:]...)
+	delete(s.overrides, id)
+	onCalls := s.onCalls[:0:0]
+	for _, onCall := range s.onCalls {
+		if onCall.Schedule.ID != id {
+			onCalls = append(onCalls, onCall)
+		}
+	}
+	s.onCalls = onCalls
+	return nil
+}
+
+// listOverrides returns the overrides of the schedule that overlap the range between since and until,
+// truncated to the range unless overflow is set
+func (s *fakeStore) listOverrides(scheduleID, since, until string, overflow bool) ([]pagerduty.Override, error) {
+	sinceTime, err := parseFakeTime("since", since)
+	if err != nil {
+		return nil, err
+	}
+	untilTime, err := parseFakeTime("until", until)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.findSchedule(scheduleID); !ok {
+		return nil, notFoundError("Schedule")
+	}
+
+	overrides := []pagerduty.Override{}
+	for _, override := range s.overrides[scheduleID] {
+		start, err := time.Parse(time.RFC3339, override.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse(time.RFC3339, override.End)
+		if err != nil {
+			continue
+		}
+		if !start.Before(untilTime) || !end.After(sinceTime) {
+			continue
+		}
+
+		if !overflow {
+			if start.Before(sinceTime) {
+				override.Start = since
+			}
+			if end.After(untilTime) {
+				override.End = until
+			}
+		}
+		overrides = append(overrides, deepCopy(override))
+	}
+	return overrides, nil
+}
+
+func (s *fakeStore) createOverride(scheduleID, userID, start, end string) (pagerduty.Override, error) {
+	startTime, err := parseFakeTime("start", start)
+	if err != nil {
+		return pagerduty.Override{}, err
+	}
+	endTime, err := parseFakeTime("end", end)
+	if err != nil {
+		return pagerduty.Override{}, err
+	}
+	if !endTime.After(startTime) {
+		return pagerduty.Override{}, newAPIError(http.StatusBadRequest, "Override must end after its start")
+	}
+
+	if _, ok := s.findSchedule(scheduleID); !ok {
+		return pagerduty.Override{}, notFoundError("Schedule")
+	}
+	i, ok := s.findUser(userID)
+	if !ok {
+		return pagerduty.Override{}, notFoundError("User")
+	}
+
+	override := builder.Override(s.newID("Q")).User(userID).Between(startTime, endTime).Build()
+	override.User.Summary = s.users[i].Name
+	s.overrides[scheduleID] = append(s.overrides[scheduleID], override)
+	return deepCopy(override), nil
+}
+
+func (s *fakeStore) removeOverride(scheduleID, overrideID string) error {
+	if _, ok := s.findSchedule(scheduleID); !ok {
+		return notFoundError("Schedule")
+	}
+	overrides := s.overrides[scheduleID]
+	for i := range overrides {
+		if overrides[i].ID == overrideID {
+			s.overrides[scheduleID] = append(overrides[:i:i], overrides[i+1:]...)
+			return nil
+		}
+	}
+	return notFoundError("Override")
+}
+
+// listIncidents returns the incidents created at or after since, and before until unless it is zero
+func (s *fakeStore) listIncidents(since, until time.Time) []pagerduty.Incident {
+	incidents := []pagerduty.Incident{}
+	for _, incident := range s.incidents {
+		if !createdSince(incident, since) || (!until.IsZero() && createdSince(incident, until)) {
+			continue
+		}
+		incidents = append(incidents, deepCopy(incident))
+	}
+	return incidents
+}
+
+// createIncident stores a new triggered incident along with the log entry of its trigger
+func (s *fakeStore) createIncident(title, serviceID, urgency, escalationPolicyID string) pagerduty.Incident {
+	var number uint
+	for _, incident := range s.incidents {
+		if incident.IncidentNumber > number {
+			number = incident.IncidentNumber
+		}
+	}
+
+	incident := builder.Incident(s.newID("Q")).
+		Number(number + 1).
+		Title(title).
+		Service(serviceID).
+		CreatedAt(s.clock.Now().UTC())
+	if len(urgency) > 0 {
+		incident.Urgency(urgency)
+	}
+	if len(escalationPolicyID) > 0 {
+		incident.EscalationPolicy(escalationPolicyID)
+	}
+	created := incident.Build()
+	s.incidents = append(s.incidents, created)
+	s.logEntries[created.ID] = []pagerduty.LogEntry{
+		{
+			CommonLogEntryField: pagerduty.CommonLogEntryField{
+				APIObject: pagerduty.APIObject{Type: "trigger_log_entry", Summary: "Triggered through the API."},
+				CreatedAt: created.CreatedAt,
+			},
+		},
+	}
+	return deepCopy(created)
+}
+
+func (s *fakeStore) listLogEntries(incidentID string) ([]pagerduty.LogEntry, error) {
+	if _, ok := s.findIncident(incidentID); !ok {
+		return nil, notFoundError("Incident")
+	}
+	return append([]pagerduty.LogEntry{}, deepCopy(s.logEntries[incidentID])...), nil
+}
+
+// updateEscalationPolicy replaces the rules of the escalation policy with those of update,
+// along with its services and teams when update has any
+func (s *fakeStore) updateEscalationPolicy(id string, update pagerduty.EscalationPolicy) (pagerduty.EscalationPolicy, error) {
+	i, ok := s.findEscalationPolicy(id)
+	if !ok {
+		return pagerduty.EscalationPolicy{}, notFoundError("Escalation Policy")
+	}
+
+	policy := s.escalationPolicies[i]
+	policy.EscalationRules = deepCopy(update.EscalationRules)
+	if len(update.Services) > 0 {
+		policy.Services = deepCopy(update.Services)
+	}
+	if len(update.Teams) > 0 {
+		policy.Teams = deepCopy(update.Teams)
+	}
+	s.escalationPolicies[i] = policy
+	return deepCopy(policy), nil
+}
+
+// listTaggedEscalationPolicies returns references to the escalation policies the tag is assigned to
+func (s *fakeStore) listTaggedEscalationPolicies(tagID string) ([]*pagerduty.APIObject, error) {
+	if _, ok := s.findTag(tagID); !ok {
+		return nil, notFoundError("Tag")
+	}
+
+	references := []*pagerduty.APIObject{}
+	for _, id := range s.taggedEscalationPolicies[tagID] {
+		i, ok := s.findEscalationPolicy(id)
+		if !ok {
+			continue
+		}
+		references = append(references, &pagerduty.APIObject{
+			ID:      id,
+			Type:    "escalation_policy_reference",
+			Summary: s.escalationPolicies[i].Name,
+		})
+	}
+	return references, nil
+}
+
+// listTags returns the tags whose label contains query
+func (s *fakeStore) listTags(query string) []*pagerduty.Tag {
+	tags := []*pagerduty.Tag{}
+	for _, tag := range s.tags {
+		if query != "" && !containsFold(tag.Label, query) {
+			continue
+		}
+		tag := deepCopy(tag)
+		tags = append(tags, &tag)
+	}
+	return tags
+}
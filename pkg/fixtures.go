@@ -0,0 +1,288 @@
+package mPagerDuty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixtures is the world a FakePDClient starts out with. Resources are spelled like the PagerDuty
+// REST API spells them, so fixture files can be written by hand or trimmed down from API responses
+//
+//	users:
+//	  - id: PJ6XOVE
+//	    name: Timur Kalandarov
+//	schedules:
+//	  - id: PUHMCXV
+//	    name: Primary
+//	    time_zone: America/Denver
+//	overrides:
+//	  PUHMCXV:
+//	    - id: Q1NF06I8X9HJAK
+//	      start: "2022-09-01T14:00:00-06:00"
+//	      end: "2022-09-02T00:00:00-06:00"
+//	      user: {id: PJ6XOVE}
+type Fixtures struct {
+	Users     []pagerduty.User     `json:"users,omitempty"`
+	Schedules []pagerduty.Schedule `json:"schedules,omitempty"`
+	// Overrides lists the overrides of each schedule by schedule ID
+	Overrides          map[string][]pagerduty.Override `json:"overrides,omitempty"`
+	OnCalls            []pagerduty.OnCall              `json:"oncalls,omitempty"`
+	Incidents          []pagerduty.Incident            `json:"incidents,omitempty"`
+	Tags               []pagerduty.Tag                 `json:"tags,omitempty"`
+	EscalationPolicies []pagerduty.EscalationPolicy    `json:"escalation_policies,omitempty"`
+	// TaggedEscalationPolicies lists the IDs of the escalation policies each tag is assigned to by tag ID
+	TaggedEscalationPolicies map[string][]string `json:"tagged_escalation_policies,omitempty"`
+	// LogEntries lists the log entries of each incident by incident ID
+	LogEntries map[string][]pagerduty.LogEntry `json:"log_entries,omitempty"`
+}
+
+// DefaultFixtures returns the world of a FakePDClient that was not given any fixtures
+func DefaultFixtures() *Fixtures {
+	fixtures := &Fixtures{
+		Users:                    []pagerduty.User{getFakedUser()},
+		Schedules:                []pagerduty.Schedule{getFakedSchedule()},
+		Overrides:                map[string][]pagerduty.Override{existingScheduleID: getFakedOverridesList().Overrides},
+		OnCalls:                  getFakedOnCalls().OnCalls,
+		Incidents:                getFakedIncidents(),
+		EscalationPolicies:       getFakedEscalationPolicies(),
+		TaggedEscalationPolicies: getFakedTaggedEscalationPolicies(),
+		LogEntries:               map[string][]pagerduty.LogEntry{},
+	}
+	for _, tag := range getFakedTags().Tags {
+		fixtures.Tags = append(fixtures.Tags, *tag)
+	}
+	for _, incident := range fixtures.Incidents {
+		fixtures.LogEntries[incident.ID] = getFakedLogEntries()
+	}
+	return fixtures
+}
+
+// LoadFixtures reads and validates the fixture files at paths, merging them in order.
+// Files ending in .json are read as JSON, files ending in .yaml or .yml as YAML.
+// Fields that PagerDuty resources do not have are rejected to catch misspellings
+func LoadFixtures(paths ...string) (*Fixtures, error) {
+	fixtures := &Fixtures{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture file: %w", err)
+		}
+
+		var file Fixtures
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			err = decodeFixtures(data, &file)
+		case ".yaml", ".yml":
+			err = decodeYAMLFixtures(data, &file)
+		default:
+			return nil, fmt.Errorf("%w: fixture file '%s' must end in .json, .yaml or .yml", ErrInvalidArgument, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to parse fixture file '%s': %v", ErrInvalidArgument, path, err)
+		}
+		fixtures.merge(file)
+	}
+
+	if err := fixtures.Validate(); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+func decodeFixtures(data []byte, fixtures *Fixtures) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(fixtures)
+}
+
+// decodeYAMLFixtures converts YAML to JSON first, as go-pagerduty only describes its resources with JSON tags
+func decodeYAMLFixtures(data []byte, fixtures *Fixtures) error {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	if document == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return decodeFixtures(data, fixtures)
+}
+
+func (fixtures *Fixtures) merge(other Fixtures) {
+	fixtures.Users = append(fixtures.Users, other.Users...)
+	fixtures.Schedules = append(fixtures.Schedules, other.Schedules...)
+	fixtures.OnCalls = append(fixtures.OnCalls, other.OnCalls...)
+	fixtures.Incidents = append(fixtures.Incidents, other.Incidents...)
+	fixtures.Tags = append(fixtures.Tags, other.Tags...)
+	fixtures.EscalationPolicies = append(fixtures.EscalationPolicies, other.EscalationPolicies...)
+	fixtures.Overrides = mergeLists(fixtures.Overrides, other.Overrides)
+	fixtures.TaggedEscalationPolicies = mergeLists(fixtures.TaggedEscalationPolicies, other.TaggedEscalationPolicies)
+	fixtures.LogEntries = mergeLists(fixtures.LogEntries, other.LogEntries)
+}
+
+func mergeLists[T any](into, from map[string][]T) map[string][]T {
+	if into == nil && len(from) > 0 {
+		into = map[string][]T{}
+	}
+	for key, values := range from {
+		into[key] = append(into[key], values...)
+	}
+	return into
+}
+
+// Validate reports every resource that is missing what PagerDuty always has, such as an ID or a name,
+// or that refers to a resource the fixtures do not define
+func (fixtures *Fixtures) Validate() error {
+	var problems []string
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	users := map[string]bool{}
+	for i, user := range fixtures.Users {
+		checkResource(report, fmt.Sprintf("users[%d]", i), user.ID, user.Name, users)
+	}
+
+	schedules := map[string]bool{}
+	for i, schedule := range fixtures.Schedules {
+		checkResource(report, fmt.Sprintf("schedules[%d]", i), schedule.ID, schedule.Name, schedules)
+		if schedule.TimeZone != "" {
+			if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+				report("schedules[%d]: unknown time zone '%s'", i, schedule.TimeZone)
+			}
+		}
+	}
+
+	for scheduleID, overrides := range fixtures.Overrides {
+		if !schedules[scheduleID] {
+			report("overrides: no schedule has ID '%s'", scheduleID)
+		}
+		ids := map[string]bool{}
+		for i, override := range overrides {
+			at := fmt.Sprintf("overrides[%s][%d]", scheduleID, i)
+			checkID(report, at, override.ID, ids)
+			checkInterval(report, at, override.Start, override.End, true)
+			if !users[override.User.ID] {
+				report("%s: no user has ID '%s'", at, override.User.ID)
+			}
+		}
+	}
+
+	escalationPolicies := map[string]bool{}
+	for i, policy := range fixtures.EscalationPolicies {
+		checkResource(report, fmt.Sprintf("escalation_policies[%d]", i), policy.ID, policy.Name, escalationPolicies)
+	}
+
+	for i, onCall := range fixtures.OnCalls {
+		at := fmt.Sprintf("oncalls[%d]", i)
+		if !users[onCall.User.ID] {
+			report("%s: no user has ID '%s'", at, onCall.User.ID)
+		}
+		if onCall.Schedule.ID != "" && !schedules[onCall.Schedule.ID] {
+			report("%s: no schedule has ID '%s'", at, onCall.Schedule.ID)
+		}
+		if onCall.EscalationPolicy.ID != "" && !escalationPolicies[onCall.EscalationPolicy.ID] {
+			report("%s: no escalation policy has ID '%s'", at, onCall.EscalationPolicy.ID)
+		}
+		checkInterval(report, at, onCall.Start, onCall.End, false)
+	}
+
+	tags := map[string]bool{}
+	for i, tag := range fixtures.Tags {
+		checkResource(report, fmt.Sprintf("tags[%d]", i), tag.ID, tag.Label, tags)
+	}
+
+	for tagID, policyIDs := range fixtures.TaggedEscalationPolicies {
+		if !tags[tagID] {
+			report("tagged_escalation_policies: no tag has ID '%s'", tagID)
+		}
+		for _, policyID := range policyIDs {
+			if !escalationPolicies[policyID] {
+				report("tagged_escalation_policies[%s]: no escalation policy has ID '%s'", tagID, policyID)
+			}
+		}
+	}
+
+	incidents := map[string]bool{}
+	for i, incident := range fixtures.Incidents {
+		checkResource(report, fmt.Sprintf("incidents[%d]", i), incident.ID, incident.Title, incidents)
+		if _, err := time.Parse(time.RFC3339, incident.CreatedAt); err != nil {
+			report("incidents[%d]: created_at '%s' is not an ISO 8601 date", i, incident.CreatedAt)
+		}
+	}
+
+	for incidentID, entries := range fixtures.LogEntries {
+		if !incidents[incidentID] {
+			report("log_entries: no incident has ID '%s'", incidentID)
+		}
+		for i, entry := range entries {
+			if entry.Type == "" {
+				report("log_entries[%s][%d]: type must be specified", incidentID, i)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: invalid fixtures: %s", ErrInvalidArgument, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkResource reports a resource without ID or name, or whose ID an earlier resource already has
+func checkResource(report func(string, ...interface{}), at, id, name string, seen map[string]bool) {
+	checkID(report, at, id, seen)
+	if strings.TrimSpace(name) == "" {
+		report("%s: name must be specified", at)
+	}
+}
+
+// checkID reports a resource without ID, or whose ID an earlier resource already has
+func checkID(report func(string, ...interface{}), at, id string, seen map[string]bool) {
+	switch {
+	case strings.TrimSpace(id) == "":
+		report("%s: id must be specified", at)
+	case seen[id]:
+		report("%s: id '%s' is not unique", at, id)
+	}
+	seen[id] = true
+}
+
+// checkInterval reports start and end dates that are missing when required, not ISO 8601 dates, or out of order
+func checkInterval(report func(string, ...interface{}), at, start, end string, required bool) {
+	if !required && start == "" && end == "" {
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		report("%s: start '%s' is not an ISO 8601 date", at, start)
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		report("%s: end '%s' is not an ISO 8601 date", at, end)
+		return
+	}
+	if !endTime.After(startTime) {
+		report("%s: end must be after start", at)
+	}
+}
+
+// WithFixtures makes clients in ModeFake start out with fixtures instead of DefaultFixtures
+func WithFixtures(fixtures *Fixtures) ClientOption {
+	return func(o *clientOptions) {
+		o.fixtures = fixtures
+	}
+}
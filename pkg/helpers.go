@@ -1,7 +1,8 @@
 package mPagerDuty
 
 import (
-	"os"
+	"context"
+	"fmt"
 	"unicode"
 
 	"github.com/PagerDuty/go-pagerduty"
@@ -12,14 +13,83 @@ import (
 
 type client struct {
 	pdClient *pagerduty.Client
+	config   Config
+	paging   paginator
+	retry    RetryPolicy
+	mode     Mode
+	observer *observer
+	clock    Clock
 }
 
-func newMPagerDutyClient(authtoken string) (IMPagerDuty, error) {
-	if os.Getenv("RUNNING_IN_JENKINS") == "true" || os.Getenv("LOCAL_DEV_TESTING") == "true" {
-		return &FakePDClient{}, nil
+func newMPagerDutyClient(authtoken string, options ...ClientOption) (IMPagerDuty, error) {
+	o := newClientOptions(options)
+	mode, err := o.resolveMode()
+	if err != nil {
+		return nil, err
 	}
 
-	return &client{pdClient: pagerduty.NewClient(authtoken)}, nil
+	switch mode {
+	case ModeLive:
+	case ModeFake:
+		// the fake sends no requests, so there is nothing to record or replay
+		if o.cassette != "" {
+			return nil, fmt.Errorf("%w: fake mode sends no requests to record into or replay from a cassette", ErrInvalidArgument)
+		}
+		config, err := o.loadConfig()
+		if err != nil {
+			return nil, err
+		}
+		fakeClient := &FakePDClient{}
+		if o.fixtures != nil {
+			if fakeClient, err = NewFakePDClient(o.fixtures); err != nil {
+				return nil, err
+			}
+		}
+		if o.faults != nil {
+			fakeClient.SetFaults(*o.faults)
+		}
+		fakeClient.SetClock(o.clock)
+		fakeClient.config = config
+		fakeClient.observer = &observer{hooks: o.hooks, tracer: o.tracer}
+		return fakeClient, nil
+	case ModeEmulator:
+		// the emulator is started, and closed, by the caller, never behind its back
+		if o.apiEndpoint == DefaultAPIEndpoint || o.apiEndpoint == EUAPIEndpoint {
+			return nil, fmt.Errorf("%w: emulator mode requires WithAPIEndpoint(emulator.URL) naming the emulator to send requests to", ErrInvalidArgument)
+		}
+	case ModeReplay:
+		if o.cassette == "" {
+			return nil, fmt.Errorf("%w: replay mode requires WithCassette naming the cassette to replay", ErrInvalidArgument)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %s", ErrInvalidArgument, mode)
+	}
+
+	if o.cassette != "" {
+		if err := o.useCassette(mode); err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := o.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pdClient := pagerduty.NewClient(authtoken, o.pagerDutyOptions()...)
+	if httpClient := o.buildHTTPClient(); httpClient != nil {
+		pdClient.HTTPClient = httpClient
+	}
+	observer := &observer{hooks: o.hooks, tracer: o.tracer}
+	if observer.enabled() {
+		pdClient.HTTPClient = &observedHTTPClient{base: pdClient.HTTPClient, observer: observer}
+	}
+	pdClient.HTTPClient = &rateLimitRecorder{base: pdClient.HTTPClient}
+
+	paging := newPaginator(config.PageSize)
+	paging.maxItems = o.maxItems
+
+	return &client{pdClient: pdClient, config: config, paging: paging, retry: o.retry, mode: mode, observer: observer, clock: o.clock}, nil
 }
 
 // Replaces non ASCII (accents, ąčęėįšųūž, etc...) characters with ASCII characters
@@ -28,3 +98,12 @@ func normalizeString(text string) (string, error) {
 	result, _, err := transform.String(t, text)
 	return result, err
 }
+
+// Prefers the context's error over the one returned by go-pagerduty, which flattens
+// a cancelled or expired request into a plain string that errors.Is cannot match
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
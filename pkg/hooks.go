@@ -0,0 +1,289 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// EventKind tells what an Event describes
+type EventKind string
+
+const (
+	// EventMethodStart is emitted when an IMPagerDuty method is called
+	EventMethodStart EventKind = "method_start"
+	// EventMethodEnd is emitted when an IMPagerDuty method returns, with its latency and error
+	EventMethodEnd EventKind = "method_end"
+	// EventRequest is emitted for every HTTP request sent to PagerDuty, with its status code and latency
+	EventRequest EventKind = "request"
+	// EventRetry is emitted when a failed request is about to be retried, with the error and the delay
+	EventRetry EventKind = "retry"
+)
+
+// Event is a structured description of something the client did
+type Event struct {
+	Kind EventKind
+	// Method is the IMPagerDuty method the event belongs to, without the WithContext suffix
+	Method string
+	// Args summarizes the arguments Method was called with
+	Args string
+	// Page is the 1-based page a paginated request fetched, 0 for requests that are not paginated
+	Page int
+	// Attempt is the 1-based attempt of a request, set for EventRequest and EventRetry
+	Attempt int
+	// HTTPMethod and Path describe the HTTP request of an EventRequest
+	HTTPMethod string
+	Path       string
+	// StatusCode is the HTTP status code of an EventRequest, 0 when no response was received
+	StatusCode int
+	// Latency is how long the method or request took, or the delay before the next attempt of an EventRetry
+	Latency time.Duration
+	Err     error
+}
+
+// Hook observes the events of a client. Implementations must be safe for concurrent use
+type Hook interface {
+	HandleEvent(ctx context.Context, event Event)
+}
+
+// HookFunc adapts an ordinary function to a Hook
+type HookFunc func(ctx context.Context, event Event)
+
+func (f HookFunc) HandleEvent(ctx context.Context, event Event) {
+	f(ctx, event)
+}
+
+// WithHooks makes the client emit its events to hooks
+func WithHooks(hooks ...Hook) ClientOption {
+	return func(o *clientOptions) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+// LogHook returns a Hook that writes every event as a key=value line to logger
+func LogHook(logger *log.Logger) Hook {
+	return HookFunc(func(ctx context.Context, event Event) {
+		fields := []string{"event=" + string(event.Kind), "method=" + event.Method}
+		if event.Args != "" {
+			fields = append(fields, fmt.Sprintf("args=%q", event.Args))
+		}
+		if event.Page > 0 {
+			fields = append(fields, fmt.Sprintf("page=%d", event.Page))
+		}
+		if event.Attempt > 0 {
+			fields = append(fields, fmt.Sprintf("attempt=%d", event.Attempt))
+		}
+		if event.HTTPMethod != "" {
+			fields = append(fields, "http_method="+event.HTTPMethod, "path="+event.Path)
+		}
+		if event.StatusCode > 0 {
+			fields = append(fields, fmt.Sprintf("status=%d", event.StatusCode))
+		}
+		if event.Kind != EventMethodStart {
+			fields = append(fields, "latency="+event.Latency.String())
+		}
+		if event.Err != nil {
+			fields = append(fields, fmt.Sprintf("error=%q", event.Err.Error()))
+		}
+		logger.Println(strings.Join(fields, " "))
+	})
+}
+
+// Tracer starts spans around every IMPagerDuty method and every HTTP request sent to PagerDuty.
+// It mirrors the shape of OpenTelemetry's trace.Tracer so that adapting one takes a few lines
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// Span is a unit of work started by a Tracer
+type Span interface {
+	SetAttributes(attributes ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a key-value pair describing a Span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// WithTracer makes the client trace its methods and HTTP requests with tracer
+func WithTracer(tracer Tracer) ClientOption {
+	return func(o *clientOptions) {
+		o.tracer = tracer
+	}
+}
+
+// observer fans the events of a client out to its hooks and tracer
+type observer struct {
+	hooks  []Hook
+	tracer Tracer
+}
+
+func (o *observer) enabled() bool {
+	return o != nil && (len(o.hooks) > 0 || o.tracer != nil)
+}
+
+func (o *observer) emit(ctx context.Context, event Event) {
+	if o == nil {
+		return
+	}
+	for _, hook := range o.hooks {
+		hook.HandleEvent(ctx, event)
+	}
+}
+
+func (o *observer) startSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	if o == nil || o.tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return o.tracer.Start(ctx, name, attributes...)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+func (noopSpan) RecordError(error)          {}
+func (noopSpan) End()                       {}
+
+type callInfoKey struct{}
+
+// callInfo describes the IMPagerDuty method a request is made for
+type callInfo struct {
+	method  string
+	args    string
+	page    int
+	attempt int
+}
+
+func callInfoFrom(ctx context.Context) callInfo {
+	info, _ := ctx.Value(callInfoKey{}).(callInfo)
+	return info
+}
+
+// withPage records in ctx which page of a paginated method a request fetches
+func withPage(ctx context.Context, page int) context.Context {
+	info := callInfoFrom(ctx)
+	info.page = page
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+// withAttempt records in ctx which attempt of a request is made
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	info := callInfoFrom(ctx)
+	info.attempt = attempt
+	return context.WithValue(ctx, callInfoKey{}, info)
+}
+
+// methodCall is an IMPagerDuty method call in progress
+type methodCall struct {
+	ctx      context.Context
+	observer *observer
+	span     Span
+	info     callInfo
+	started  time.Time
+}
+
+// startCall emits the start of method and returns ctx carrying its span. Calling end with
+// the address of the method's error result emits its end
+func (c *client) startCall(ctx context.Context, method string, args ...interface{}) (context.Context, *methodCall) {
+	return c.observer.startCall(ctx, method, args)
+}
+
+// startCall is startCall of the client, shared with FakePDClient, which observes its methods alike
+func (o *observer) startCall(ctx context.Context, method string, args []interface{}) (context.Context, *methodCall) {
+	if !o.enabled() {
+		return ctx, nil
+	}
+
+	info := callInfo{method: method, args: summarizeArgs(args)}
+	ctx, span := o.startSpan(ctx, "mPagerDuty."+method, Attribute{Key: "pagerduty.method", Value: method}, Attribute{Key: "pagerduty.args", Value: info.args})
+	ctx = context.WithValue(ctx, callInfoKey{}, info)
+	call := &methodCall{ctx: ctx, observer: o, span: span, info: info, started: time.Now()}
+	call.observer.emit(ctx, Event{Kind: EventMethodStart, Method: method, Args: info.args})
+	return ctx, call
+}
+
+func (call *methodCall) end(err *error) {
+	if call == nil {
+		return
+	}
+
+	if *err != nil {
+		call.span.RecordError(*err)
+	}
+	call.span.End()
+	call.observer.emit(call.ctx, Event{Kind: EventMethodEnd, Method: call.info.method, Args: call.info.args, Latency: time.Since(call.started), Err: *err})
+}
+
+// summarizeArgs renders arguments compactly enough for a log line or span attribute
+func summarizeArgs(args []interface{}) string {
+	const maxLength = 200
+
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case string:
+			parts = append(parts, fmt.Sprintf("%q", arg))
+		case time.Duration:
+			parts = append(parts, arg.String())
+		default:
+			parts = append(parts, fmt.Sprintf("%+v", arg))
+		}
+	}
+
+	summary := strings.Join(parts, ", ")
+	if len(summary) > maxLength {
+		summary = summary[:maxLength] + "..."
+	}
+	return summary
+}
+
+// observedHTTPClient emits an EventRequest and a span for every HTTP request
+type observedHTTPClient struct {
+	base     pagerduty.HTTPClient
+	observer *observer
+}
+
+func (o *observedHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	info := callInfoFrom(req.Context())
+	ctx, span := o.observer.startSpan(req.Context(), "HTTP "+req.Method,
+		Attribute{Key: "http.method", Value: req.Method},
+		Attribute{Key: "http.target", Value: req.URL.Path},
+		Attribute{Key: "pagerduty.method", Value: info.method},
+		Attribute{Key: "pagerduty.page", Value: info.page})
+	req = req.WithContext(ctx)
+
+	started := time.Now()
+	resp, err := o.base.Do(req)
+	latency := time.Since(started)
+
+	event := Event{
+		Kind:       EventRequest,
+		Method:     info.method,
+		Args:       info.args,
+		Page:       info.page,
+		Attempt:    info.attempt,
+		HTTPMethod: req.Method,
+		Path:       req.URL.Path,
+		Latency:    latency,
+		Err:        err,
+	}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+		span.SetAttributes(Attribute{Key: "http.status_code", Value: resp.StatusCode})
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+
+	o.observer.emit(ctx, event)
+	return resp, err
+}
@@ -11,45 +11,84 @@ import (
 
 // MPagerDuty is a mockable interface that is used to deliver a Client object to dependent services
 // and test without actually sending API requests to the Jira instance
+//
+// Every method has a ...WithContext variant that propagates cancellation and deadlines
+// into each underlying API request. The plain variants use context.Background()
 type IMPagerDuty interface {
 	// On-Calls
 	GetOnCallsByScheduleIDs(scheduleIDs []string) ([]pagerduty.OnCall, error)
+	GetOnCallsByScheduleIDsWithContext(ctx context.Context, scheduleIDs []string) ([]pagerduty.OnCall, error)
 	GetOnCallsWithOptions(*pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error)
+	GetOnCallsWithOptionsWithContext(ctx context.Context, options *pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error)
+	GetOnCallsAt(scheduleIDs, escalationPolicyIDs []string, at time.Time) ([]OnCallLevel, error)
+	GetOnCallsAtWithContext(ctx context.Context, scheduleIDs, escalationPolicyIDs []string, at time.Time) ([]OnCallLevel, error)
 
 	// Users
 	ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.User, error)
+	ListAllUsersWithContext(ctx context.Context, options pagerduty.ListUsersOptions) ([]pagerduty.User, error)
 	GetUserByID(id string, options pagerduty.GetUserOptions) (*pagerduty.User, error)
+	GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (*pagerduty.User, error)
 	GetUserIDbyName(name string) (string, error)
+	GetUserIDbyNameWithContext(ctx context.Context, name string) (string, error)
 	GetUsersIDsByNames(names []string) ([]string, error)
+	GetUsersIDsByNamesWithContext(ctx context.Context, names []string) ([]string, error)
 
 	// Schedules
 	GetScheduleIDbyName(name string) (string, string, error)
+	GetScheduleIDbyNameWithContext(ctx context.Context, name string) (string, string, error)
+	ResolveSchedule(name string) (*ScheduleMatch, error)
+	ResolveScheduleWithContext(ctx context.Context, name string) (*ScheduleMatch, error)
+	GetScheduleByID(id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error)
+	GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error)
+	ListSchedules(options ListSchedulesOptions) ([]pagerduty.Schedule, error)
+	ListSchedulesWithContext(ctx context.Context, options ListSchedulesOptions) ([]pagerduty.Schedule, error)
+	CreateSchedule(schedule pagerduty.Schedule) (*pagerduty.Schedule, error)
+	CreateScheduleWithContext(ctx context.Context, schedule pagerduty.Schedule) (*pagerduty.Schedule, error)
+	UpdateSchedule(id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error)
+	UpdateScheduleWithContext(ctx context.Context, id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error)
+	DeleteSchedule(id string) error
+	DeleteScheduleWithContext(ctx context.Context, id string) error
+	GetRenderedSchedule(scheduleID string, since, until time.Time, timeZone string) (*RenderedSchedule, error)
+	GetRenderedScheduleWithContext(ctx context.Context, scheduleID string, since, until time.Time, timeZone string) (*RenderedSchedule, error)
 
 	// Overrides
 	GetOverrides(scheduleID, since, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error)
+	GetOverridesWithContext(ctx context.Context, scheduleID, since, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error)
 	CreateOverride(scheduleID string, userID string, start string, end string) (*pagerduty.Override, error)
+	CreateOverrideWithContext(ctx context.Context, scheduleID string, userID string, start string, end string) (*pagerduty.Override, error)
 	RemoveOverride(scheduleID string, overrideID string) error
+	RemoveOverrideWithContext(ctx context.Context, scheduleID string, overrideID string) error
 
 	// Tags
 	ListAllTags(options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error)
+	ListAllTagsWithContext(ctx context.Context, options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error)
 
 	// Incidents
 	GetIndicentsByEscalationPolicy(escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error)
+	GetIndicentsByEscalationPolicyWithContext(ctx context.Context, escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error)
 	GetIndicentsByTag(tagName string, timeRange time.Duration) ([]pagerduty.Incident, error)
+	GetIndicentsByTagWithContext(ctx context.Context, tagName string, timeRange time.Duration) ([]pagerduty.Incident, error)
 	CreateIncident(title, serviceID, urgency, details, escalationPolicyID string) (*pagerduty.Incident, error)
+	CreateIncidentWithContext(ctx context.Context, title, serviceID, urgency, details, escalationPolicyID string) (*pagerduty.Incident, error)
 	SearchIncidents(serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error)
+	SearchIncidentsWithContext(ctx context.Context, serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error)
 	SearchIncidentLogs(pdIncidentId string, logType string) (*string, error)
+	SearchIncidentLogsWithContext(ctx context.Context, pdIncidentId string, logType string) (*string, error)
 
 	// Escalation Policies
 	GetEscalationPoliciesByTag(tagID string) (*pagerduty.ListEPResponse, error)
+	GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (*pagerduty.ListEPResponse, error)
 	UpdateEscalationPolicy(id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error)
+	UpdateEscalationPolicyWithContext(ctx context.Context, id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error)
 }
 
-// GetMPagerDutyClient creates a usable actual go-pagerduty client or a usable faked client
-// that returns dummy data when environment variables RUNNING_IN_JENKINS or LOCAL_DEV_TESTING
-// are set to true
-func GetMPagerDutyClient(authtoken string) (IMPagerDuty, error) {
-	pdClient, err := newMPagerDutyClient(authtoken)
+// GetMPagerDutyClient creates a usable actual go-pagerduty client, or a client of another mode
+// when WithMode is passed. WithModeFromEnv opts into returning a usable faked client that returns
+// dummy data when environment variables RUNNING_IN_JENKINS or LOCAL_DEV_TESTING are set to true
+//
+// The actual client can be customized with options such as WithAPIEndpoint, WithHTTPClient or WithFrom
+func GetMPagerDutyClient(authtoken string, options ...ClientOption) (IMPagerDuty, error) {
+	pdClient, err := newMPagerDutyClient(authtoken, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -59,39 +98,45 @@ func GetMPagerDutyClient(authtoken string) (IMPagerDuty, error) {
 // GetOnCallsByScheduleIDs returns the list of all on-calls for the specified schedule IDs
 // API documentation: https://developer.pagerduty.com/api-reference/3a6b910f11050-list-all-of-the-on-calls
 func (c *client) GetOnCallsByScheduleIDs(scheduleIDs []string) ([]pagerduty.OnCall, error) {
+	return c.GetOnCallsByScheduleIDsWithContext(context.Background(), scheduleIDs)
+}
+
+// GetOnCallsByScheduleIDsWithContext is GetOnCallsByScheduleIDs that stops paging as soon as ctx is done
+func (c *client) GetOnCallsByScheduleIDsWithContext(ctx context.Context, scheduleIDs []string) (_ []pagerduty.OnCall, err error) {
+	ctx, call := c.startCall(ctx, "GetOnCallsByScheduleIDs", scheduleIDs)
+	defer call.end(&err)
+
 	if scheduleIDs == nil {
-		return nil, fmt.Errorf("array of scheduleIDs must be defined")
+		return nil, fmt.Errorf("%w: array of scheduleIDs must be defined", ErrInvalidArgument)
 	}
 
 	if len(scheduleIDs) < 1 {
-		return nil, fmt.Errorf("could not get on-calls because scheduleIDs was empty")
+		return nil, fmt.Errorf("%w: could not get on-calls because scheduleIDs was empty", ErrInvalidArgument)
 	}
 
 	for i := 0; i < len(scheduleIDs); i++ {
 		if len(strings.TrimSpace(scheduleIDs[i])) == 0 {
-			return nil, fmt.Errorf("could not get on-calls because the passed array had empty string(s)")
+			return nil, fmt.Errorf("%w: could not get on-calls because the passed array had empty string(s)", ErrInvalidArgument)
 		}
 	}
 
-	var onCalls []pagerduty.OnCall
-	var offset uint = 0
-	for {
-		response, err := c.pdClient.ListOnCallsWithContext(context.Background(), pagerduty.ListOnCallOptions{
-			TimeZone:    "UTC",
-			ScheduleIDs: scheduleIDs,
-			Limit:       limit,
-			Total:       true,
-			Offset:      offset,
+	onCalls, err := collectPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.OnCall, pagerduty.APIListObject, error) {
+		response, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListOnCallsResponse, error) {
+			return c.pdClient.ListOnCallsWithContext(ctx, pagerduty.ListOnCallOptions{
+				TimeZone:    c.config.DefaultTimeZone,
+				ScheduleIDs: scheduleIDs,
+				Limit:       limit,
+				Total:       true,
+				Offset:      offset,
+			})
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to list on calls: %s", err)
-		}
-
-		onCalls = append(onCalls, response.OnCalls...)
-		if len(response.OnCalls) < 1 {
-			break
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
 		}
-		offset = offset + increase
+		return response.OnCalls, response.APIListObject, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on calls: %w", err)
 	}
 
 	return onCalls, nil
@@ -99,89 +144,359 @@ func (c *client) GetOnCallsByScheduleIDs(scheduleIDs []string) ([]pagerduty.OnCa
 
 // GetOnCallsWithOptions returns the list of on-calls that satisfy the specified options query
 func (c *client) GetOnCallsWithOptions(options *pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error) {
-	onCalls, error := c.pdClient.ListOnCallsWithContext(context.Background(), *options)
-	return onCalls, error
+	return c.GetOnCallsWithOptionsWithContext(context.Background(), options)
+}
+
+// GetOnCallsWithOptionsWithContext is GetOnCallsWithOptions bound to ctx
+func (c *client) GetOnCallsWithOptionsWithContext(ctx context.Context, options *pagerduty.ListOnCallOptions) (_ *pagerduty.ListOnCallsResponse, err error) {
+	ctx, call := c.startCall(ctx, "GetOnCallsWithOptions", options)
+	defer call.end(&err)
+
+	onCalls, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListOnCallsResponse, error) {
+		return c.pdClient.ListOnCallsWithContext(ctx, *options)
+	})
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return onCalls, nil
+}
+
+// GetOnCallsAt returns who is on call at every escalation level at the time at, for the schedules
+// and the escalation policies specified by ID. Levels whose rules only target users directly are
+// left out when filtering by schedule, like PagerDuty does
+func (c *client) GetOnCallsAt(scheduleIDs, escalationPolicyIDs []string, at time.Time) ([]OnCallLevel, error) {
+	return c.GetOnCallsAtWithContext(context.Background(), scheduleIDs, escalationPolicyIDs, at)
+}
+
+// GetOnCallsAtWithContext is GetOnCallsAt that stops paging as soon as ctx is done
+func (c *client) GetOnCallsAtWithContext(ctx context.Context, scheduleIDs, escalationPolicyIDs []string, at time.Time) (_ []OnCallLevel, err error) {
+	ctx, call := c.startCall(ctx, "GetOnCallsAt", scheduleIDs, escalationPolicyIDs, at)
+	defer call.end(&err)
+
+	if err := validateOnCallsAt(scheduleIDs, escalationPolicyIDs, at); err != nil {
+		return nil, err
+	}
+
+	onCalls, err := collectPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.OnCall, pagerduty.APIListObject, error) {
+		options := onCallsAtOptions(scheduleIDs, escalationPolicyIDs, at, c.config.DefaultTimeZone)
+		options.Limit = limit
+		options.Offset = offset
+		response, err := c.GetOnCallsWithOptionsWithContext(ctx, &options)
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, err
+		}
+		return response.OnCalls, response.APIListObject, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on calls: %w", err)
+	}
+	return newOnCallLevels(onCalls, at)
 }
 
 // GetScheduleIDbyName returns ID and timezone for the schedule specified by name
 // API documentation: https://developer.pagerduty.com/api-reference/3f03afb2c84a4-get-a-schedule
 func (c *client) GetScheduleIDbyName(name string) (string, string, error) {
+	return c.GetScheduleIDbyNameWithContext(context.Background(), name)
+}
+
+// GetScheduleIDbyNameWithContext is GetScheduleIDbyName that stops paging as soon as ctx is done
+func (c *client) GetScheduleIDbyNameWithContext(ctx context.Context, name string) (_ string, _ string, err error) {
+	ctx, call := c.startCall(ctx, "GetScheduleIDbyName", name)
+	defer call.end(&err)
+
 	if strings.TrimSpace(name) == "" {
-		return "", "", fmt.Errorf("passed parameter 'name' must be specified")
+		return "", "", fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
 	}
 
 	var resp string = ""
 	var tz string = ""
-	var offset uint = 0
 
-	for {
-		schedules, err := c.pdClient.ListSchedulesWithContext(
-			context.Background(),
-			pagerduty.ListSchedulesOptions{
+	err = walkPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.Schedule, pagerduty.APIListObject, error) {
+		schedules, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListSchedulesResponse, error) {
+			return c.pdClient.ListSchedulesWithContext(
+				ctx,
+				pagerduty.ListSchedulesOptions{
+					Limit:  limit,
+					Offset: offset,
+					Query:  ""})
+		})
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
+		}
+		return schedules.Schedules, schedules.APIListObject, nil
+	}, func(schedule pagerduty.Schedule) (bool, error) {
+		if strings.EqualFold(schedule.Name, c.config.scheduleTitle(name)) {
+			resp = schedule.ID
+			tz = schedule.TimeZone
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("error in getting schedules from PagerDuty: %w", err)
+	}
+	return resp, tz, nil
+}
+
+// ResolveSchedule returns the schedule that name matches best, with or without the configured schedule prefix,
+// regardless of case and diacritics, and allowing for partial names and typos. Unlike GetScheduleIDbyName,
+// it fails with ErrNotFound when no schedule matches, and with an *AmbiguousNameError listing the candidates
+// when several schedules match equally well
+func (c *client) ResolveSchedule(name string) (*ScheduleMatch, error) {
+	return c.ResolveScheduleWithContext(context.Background(), name)
+}
+
+// ResolveScheduleWithContext is ResolveSchedule that stops paging as soon as ctx is done
+func (c *client) ResolveScheduleWithContext(ctx context.Context, name string) (_ *ScheduleMatch, err error) {
+	ctx, call := c.startCall(ctx, "ResolveSchedule", name)
+	defer call.end(&err)
+
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
+	}
+
+	schedules, err := c.ListSchedulesWithContext(ctx, ListSchedulesOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return resolveScheduleName(schedules, name, c.config.SchedulePrefix)
+}
+
+// ListSchedulesOptions filters the schedules ListSchedules returns
+type ListSchedulesOptions struct {
+	// Query matches the schedules whose name contains it, regardless of case
+	Query string
+	// TeamIDs matches the schedules that belong to one of the teams, unless it is empty.
+	// PagerDuty cannot filter schedules by team, so they are filtered once they are listed
+	TeamIDs []string
+}
+
+// scheduleInTeams reports whether the schedule belongs to one of teamIDs, or teamIDs is empty
+func scheduleInTeams(schedule pagerduty.Schedule, teamIDs []string) bool {
+	if len(teamIDs) == 0 {
+		return true
+	}
+	for _, team := range schedule.Teams {
+		if containsString(teamIDs, team.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSchedule checks the fields PagerDuty requires of the schedules it creates and updates
+func validateSchedule(schedule pagerduty.Schedule) error {
+	if strings.TrimSpace(schedule.TimeZone) == "" {
+		return fmt.Errorf("%w: passed schedule must have a 'TimeZone'", ErrInvalidArgument)
+	}
+	if len(schedule.ScheduleLayers) < 1 {
+		return fmt.Errorf("%w: passed schedule must have at least one layer", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// GetScheduleByID returns *pagerduty.Schedule object for the schedule specified by ID
+// API documentation: https://developer.pagerduty.com/api-reference/3f03afb2c84a4-get-a-schedule
+func (c *client) GetScheduleByID(id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	return c.GetScheduleByIDWithContext(context.Background(), id, options)
+}
+
+// GetScheduleByIDWithContext is GetScheduleByID bound to ctx
+func (c *client) GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (_ *pagerduty.Schedule, err error) {
+	ctx, call := c.startCall(ctx, "GetScheduleByID", id, options)
+	defer call.end(&err)
+
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
+	}
+
+	schedule, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.Schedule, error) {
+		return c.pdClient.GetScheduleWithContext(ctx, id, options)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pagerduty schedule: %w", contextError(ctx, err))
+	}
+	return schedule, nil
+}
+
+// GetRenderedSchedule returns who the schedule specified by ID puts on call between since and until:
+// the final schedule, the overrides and every layer, in timeZone or in the time zone of the schedule when timeZone is empty
+func (c *client) GetRenderedSchedule(scheduleID string, since, until time.Time, timeZone string) (*RenderedSchedule, error) {
+	return c.GetRenderedScheduleWithContext(context.Background(), scheduleID, since, until, timeZone)
+}
+
+// GetRenderedScheduleWithContext is GetRenderedSchedule bound to ctx
+func (c *client) GetRenderedScheduleWithContext(ctx context.Context, scheduleID string, since, until time.Time, timeZone string) (_ *RenderedSchedule, err error) {
+	ctx, call := c.startCall(ctx, "GetRenderedSchedule", scheduleID, since, until, timeZone)
+	defer call.end(&err)
+
+	location, err := validateRenderWindow(scheduleID, since, until, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := c.GetScheduleByIDWithContext(ctx, scheduleID, renderOptions(since, until, timeZone))
+	if err != nil {
+		return nil, err
+	}
+	return newRenderedSchedule(schedule, since, until, location)
+}
+
+// ListSchedules returns the schedules whose name contains options.Query
+// and that belong to one of options.TeamIDs, when these are set
+func (c *client) ListSchedules(options ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	return c.ListSchedulesWithContext(context.Background(), options)
+}
+
+// ListSchedulesWithContext is ListSchedules that stops paging as soon as ctx is done
+func (c *client) ListSchedulesWithContext(ctx context.Context, options ListSchedulesOptions) (_ []pagerduty.Schedule, err error) {
+	ctx, call := c.startCall(ctx, "ListSchedules", options)
+	defer call.end(&err)
+
+	schedules := []pagerduty.Schedule{}
+	err = walkPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.Schedule, pagerduty.APIListObject, error) {
+		response, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListSchedulesResponse, error) {
+			return c.pdClient.ListSchedulesWithContext(ctx, pagerduty.ListSchedulesOptions{
 				Limit:  limit,
 				Offset: offset,
-				Query:  ""})
+				Query:  options.Query,
+			})
+		})
 		if err != nil {
-			return resp, tz, fmt.Errorf("error in getting schedules from PagerDuty: %s", err)
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
 		}
-		if len(schedules.Schedules) < 1 {
-			break
-		} else {
-			for i := range schedules.Schedules {
-				if strings.EqualFold(schedules.Schedules[i].Name, pdScheduleTitlePrefix+" "+name) {
-					resp = schedules.Schedules[i].ID
-					tz = schedules.Schedules[i].TimeZone
-					break
-				}
-			}
-			if resp != "" {
-				break
-			}
+		return response.Schedules, response.APIListObject, nil
+	}, func(schedule pagerduty.Schedule) (bool, error) {
+		if scheduleInTeams(schedule, options.TeamIDs) {
+			schedules = append(schedules, schedule)
 		}
-		offset = offset + increase
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
 	}
-	return resp, tz, nil
+	return schedules, nil
+}
+
+// CreateSchedule creates the schedule, which needs a time zone and at least one layer,
+// and returns a newly created *pagerduty.Schedule object in case of success, and error, in case of failure
+func (c *client) CreateSchedule(schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return c.CreateScheduleWithContext(context.Background(), schedule)
+}
+
+// CreateScheduleWithContext is CreateSchedule bound to ctx
+func (c *client) CreateScheduleWithContext(ctx context.Context, schedule pagerduty.Schedule) (_ *pagerduty.Schedule, err error) {
+	ctx, call := c.startCall(ctx, "CreateSchedule", schedule)
+	defer call.end(&err)
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	schedule.Type = "schedule"
+
+	newSchedule, err := withRetry(ctx, c, nonIdempotent, func(ctx context.Context) (*pagerduty.Schedule, error) {
+		return c.pdClient.CreateScheduleWithContext(ctx, schedule)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while creating schedule on PagerDuty: %w", contextError(ctx, err))
+	}
+	return newSchedule, nil
+}
+
+// UpdateSchedule replaces the schedule specified by ID with schedule, which needs a time zone
+// and at least one layer like the schedules CreateSchedule creates, and returns the updated schedule
+func (c *client) UpdateSchedule(id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return c.UpdateScheduleWithContext(context.Background(), id, schedule)
+}
+
+// UpdateScheduleWithContext is UpdateSchedule bound to ctx
+func (c *client) UpdateScheduleWithContext(ctx context.Context, id string, schedule pagerduty.Schedule) (_ *pagerduty.Schedule, err error) {
+	ctx, call := c.startCall(ctx, "UpdateSchedule", id, schedule)
+	defer call.end(&err)
+
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
+	}
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	schedule.Type = "schedule"
+
+	updatedSchedule, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.Schedule, error) {
+		return c.pdClient.UpdateScheduleWithContext(ctx, id, schedule)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while updating schedule on PagerDuty: %w", contextError(ctx, err))
+	}
+	return updatedSchedule, nil
+}
+
+// DeleteSchedule deletes the schedule specified by ID
+func (c *client) DeleteSchedule(id string) error {
+	return c.DeleteScheduleWithContext(context.Background(), id)
+}
+
+// DeleteScheduleWithContext is DeleteSchedule bound to ctx
+func (c *client) DeleteScheduleWithContext(ctx context.Context, id string) (err error) {
+	ctx, call := c.startCall(ctx, "DeleteSchedule", id)
+	defer call.end(&err)
+
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
+	}
+
+	_, err = withRetry(ctx, c, idempotent, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.pdClient.DeleteScheduleWithContext(ctx, id)
+	})
+	if err != nil {
+		return fmt.Errorf("error while deleting schedule on PagerDuty: %w", contextError(ctx, err))
+	}
+	return nil
 }
 
 // GetUserIDbyName returns ID for the user specified by name
 func (c *client) GetUserIDbyName(name string) (string, error) {
+	return c.GetUserIDbyNameWithContext(context.Background(), name)
+}
+
+// GetUserIDbyNameWithContext is GetUserIDbyName that stops paging as soon as ctx is done
+func (c *client) GetUserIDbyNameWithContext(ctx context.Context, name string) (_ string, err error) {
+	ctx, call := c.startCall(ctx, "GetUserIDbyName", name)
+	defer call.end(&err)
+
 	if strings.TrimSpace(name) == "" {
-		return "", fmt.Errorf("passed parameter 'name' must be specified")
+		return "", fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
 	}
 
 	var id string = ""
-	var offset uint = 0
 
-	for {
-		users, err := c.pdClient.ListUsersWithContext(
-			context.Background(),
-			pagerduty.ListUsersOptions{
-				Limit:   limit,
-				Offset:  offset,
-				TeamIDs: pgGSOCTeamID})
+	err = walkPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.User, pagerduty.APIListObject, error) {
+		users, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListUsersResponse, error) {
+			return c.pdClient.ListUsersWithContext(
+				ctx,
+				pagerduty.ListUsersOptions{
+					Limit:   limit,
+					Offset:  offset,
+					TeamIDs: c.config.TeamIDs})
+		})
 		if err != nil {
-			return "", fmt.Errorf("error in getting userID from PagerDuty: %s", err)
+			return nil, pagerduty.APIListObject{}, fmt.Errorf("error in getting userID from PagerDuty: %w", contextError(ctx, err))
+		}
+		return users.Users, users.APIListObject, nil
+	}, func(user pagerduty.User) (bool, error) {
+		normalizedName, err := normalizeString(user.Name)
+		if err != nil {
+			return false, fmt.Errorf("error while normalizing user name '%s': %w", user.Name, err)
 		}
 
-		if len(users.Users) < 1 {
-			break
-		} else {
-			for i := range users.Users {
-				normalizedName, err := normalizeString(users.Users[i].Name)
-				if err != nil {
-					return "", fmt.Errorf("error while normalizing user name '%s': %s", users.Users[i].Name, err)
-				}
-
-				if strings.EqualFold(normalizedName, name) {
-					id = users.Users[i].ID
-					break
-				}
-			}
-			if id != "" {
-				break
-			}
+		if strings.EqualFold(normalizedName, name) {
+			id = user.ID
+			return false, nil
 		}
-		offset = offset + increase
+		return true, nil
+	})
+	if err != nil {
+		return "", err
 	}
 	return id, nil
 }
@@ -189,16 +504,26 @@ func (c *client) GetUserIDbyName(name string) (string, error) {
 // GetUserByID returns *pagerduty.User object for the user specified by ID
 // API documentation: https://developer.pagerduty.com/api-reference/2395ca1feb25e-get-a-user
 func (c *client) GetUserByID(id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	return c.GetUserByIDWithContext(context.Background(), id, options)
+}
+
+// GetUserByIDWithContext is GetUserByID bound to ctx
+func (c *client) GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (_ *pagerduty.User, err error) {
+	ctx, call := c.startCall(ctx, "GetUserByID", id, options)
+	defer call.end(&err)
+
 	if strings.TrimSpace(id) == "" {
-		return nil, fmt.Errorf("passed parameter 'id' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
 	}
 
-	user, err := c.pdClient.GetUserWithContext(
-		context.Background(),
-		id, options)
+	user, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.User, error) {
+		return c.pdClient.GetUserWithContext(
+			ctx,
+			id, options)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pagerduty user: %s", err)
+		return nil, fmt.Errorf("failed to get pagerduty user: %w", contextError(ctx, err))
 	}
 
 	return user, nil
@@ -207,22 +532,27 @@ func (c *client) GetUserByID(id string, options pagerduty.GetUserOptions) (*page
 // ListAllUsers returns *pagerduty.ListUsersResponse object that contains infotmation about all PagerDuty users
 // API documentation: https://developer.pagerduty.com/api-reference/c96e889522dd6-list-users
 func (c *client) ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
-	options.Limit = limit
-	options.Offset = 0
+	return c.ListAllUsersWithContext(context.Background(), options)
+}
 
-	var users []pagerduty.User
-	var offset uint = 0
-	for {
-		response, err := c.pdClient.ListUsersWithContext(context.Background(), options)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list users: %s", err)
-		}
+// ListAllUsersWithContext is ListAllUsers that stops paging as soon as ctx is done
+func (c *client) ListAllUsersWithContext(ctx context.Context, options pagerduty.ListUsersOptions) (_ []pagerduty.User, err error) {
+	ctx, call := c.startCall(ctx, "ListAllUsers", options)
+	defer call.end(&err)
 
-		users = append(users, response.Users...)
-		if len(response.Users) < 1 {
-			break
+	users, err := collectPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.User, pagerduty.APIListObject, error) {
+		options.Limit = limit
+		options.Offset = offset
+		response, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListUsersResponse, error) {
+			return c.pdClient.ListUsersWithContext(ctx, options)
+		})
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
 		}
-		offset = offset + increase
+		return response.Users, response.APIListObject, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
 
 	return users, nil
@@ -231,23 +561,33 @@ func (c *client) ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.U
 // GetOverrides returns *pagerduty.ListOverridesResponse object for the specified name, schedule ID, start and end dates
 // API documentation: https://developer.pagerduty.com/api-reference/cb747199f63a9-list-overrides
 func (c *client) GetOverrides(scheduleID, since, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error) {
+	return c.GetOverridesWithContext(context.Background(), scheduleID, since, until, includeOverflow)
+}
+
+// GetOverridesWithContext is GetOverrides bound to ctx
+func (c *client) GetOverridesWithContext(ctx context.Context, scheduleID, since, until string, includeOverflow bool) (_ *pagerduty.ListOverridesResponse, err error) {
+	ctx, call := c.startCall(ctx, "GetOverrides", scheduleID, since, until, includeOverflow)
+	defer call.end(&err)
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return nil, fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 
 	if strings.TrimSpace(since) == "" || strings.TrimSpace(until) == "" {
-		return nil, fmt.Errorf("passed parameters 'since' and 'until' dates must be specified")
+		return nil, fmt.Errorf("%w: passed parameters 'since' and 'until' dates must be specified", ErrInvalidArgument)
 	}
 
-	overrides, err := c.pdClient.ListOverridesWithContext(
-		context.Background(),
-		scheduleID, pagerduty.ListOverridesOptions{
-			Since:    since,
-			Until:    until,
-			Overflow: includeOverflow, // unless this parameter is set to true, any entry that passes the date range bounds will be truncated at the bounds
-		})
+	overrides, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListOverridesResponse, error) {
+		return c.pdClient.ListOverridesWithContext(
+			ctx,
+			scheduleID, pagerduty.ListOverridesOptions{
+				Since:    since,
+				Until:    until,
+				Overflow: includeOverflow, // unless this parameter is set to true, any entry that passes the date range bounds will be truncated at the bounds
+			})
+	})
 	if err != nil {
-		return nil, err
+		return nil, contextError(ctx, err)
 	}
 	return overrides, nil
 }
@@ -255,40 +595,60 @@ func (c *client) GetOverrides(scheduleID, since, until string, includeOverflow b
 // CreateOverride creates override for the specified by ID schedule and user, as well as start and end dates,
 // and returns a newly created *pagerduty.Override object in case of success, and error, in case of failure
 func (c *client) CreateOverride(scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	return c.CreateOverrideWithContext(context.Background(), scheduleID, userID, start, end)
+}
+
+// CreateOverrideWithContext is CreateOverride bound to ctx
+func (c *client) CreateOverrideWithContext(ctx context.Context, scheduleID string, userID string, start string, end string) (_ *pagerduty.Override, err error) {
+	ctx, call := c.startCall(ctx, "CreateOverride", scheduleID, userID, start, end)
+	defer call.end(&err)
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return nil, fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(userID) == "" {
-		return nil, fmt.Errorf("passed parameter 'userID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'userID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(start) == "" {
-		return nil, fmt.Errorf("passed parameter 'start' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'start' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(end) == "" {
-		return nil, fmt.Errorf("passed parameter 'end' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'end' must be specified", ErrInvalidArgument)
 	}
 
-	newOverride, err := c.pdClient.CreateOverrideWithContext(
-		context.Background(),
-		scheduleID, pagerduty.Override{Start: start, End: end, User: pagerduty.APIObject{ID: userID, Type: "user"}})
+	newOverride, err := withRetry(ctx, c, nonIdempotent, func(ctx context.Context) (*pagerduty.Override, error) {
+		return c.pdClient.CreateOverrideWithContext(
+			ctx,
+			scheduleID, pagerduty.Override{Start: start, End: end, User: pagerduty.APIObject{ID: userID, Type: "user"}})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error while creating override on PagerDuty: %s", err)
+		return nil, fmt.Errorf("error while creating override on PagerDuty: %w", contextError(ctx, err))
 	}
 	return newOverride, nil
 }
 
 // RemoveOverride deletes override specified by its ID and schedule ID
 func (c *client) RemoveOverride(scheduleID string, overrideID string) error {
+	return c.RemoveOverrideWithContext(context.Background(), scheduleID, overrideID)
+}
+
+// RemoveOverrideWithContext is RemoveOverride bound to ctx
+func (c *client) RemoveOverrideWithContext(ctx context.Context, scheduleID string, overrideID string) (err error) {
+	ctx, call := c.startCall(ctx, "RemoveOverride", scheduleID, overrideID)
+	defer call.end(&err)
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(overrideID) == "" {
-		return fmt.Errorf("passed parameter 'overrideID' must be specified")
+		return fmt.Errorf("%w: passed parameter 'overrideID' must be specified", ErrInvalidArgument)
 	}
 
-	err := c.pdClient.DeleteOverrideWithContext(context.Background(), scheduleID, overrideID)
+	_, err = withRetry(ctx, c, idempotent, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.pdClient.DeleteOverrideWithContext(ctx, scheduleID, overrideID)
+	})
 	if err != nil {
-		return fmt.Errorf("error while removing override on PagerDuty: %s", err)
+		return fmt.Errorf("error while removing override on PagerDuty: %w", contextError(ctx, err))
 	}
 	return nil
 }
@@ -298,43 +658,30 @@ func (c *client) RemoveOverride(scheduleID string, overrideID string) error {
 //
 // API documentation: https://developer.pagerduty.com/api-reference/5a579467410f7-get-connected-entities
 func (c *client) GetIndicentsByEscalationPolicy(escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	return c.GetIndicentsByEscalationPolicyWithContext(context.Background(), escalationPolicyID, timeRange)
+}
+
+// GetIndicentsByEscalationPolicyWithContext is GetIndicentsByEscalationPolicy that stops paging as soon as ctx is done
+func (c *client) GetIndicentsByEscalationPolicyWithContext(ctx context.Context, escalationPolicyID string, timeRange time.Duration) (_ []pagerduty.Incident, err error) {
+	ctx, call := c.startCall(ctx, "GetIndicentsByEscalationPolicy", escalationPolicyID, timeRange)
+	defer call.end(&err)
+
 	if strings.TrimSpace(escalationPolicyID) == "" {
-		return nil, fmt.Errorf("passed parameter 'escalationPolicyID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'escalationPolicyID' must be specified", ErrInvalidArgument)
 	}
 
-	UTC, err := time.LoadLocation("UTC")
-	if err != nil {
-		return nil, err
-	}
-	var since = ""
-	querySinceTime := time.Now().Add(timeRange * time.Minute).In(UTC).Format("2006-01-02T15:04:05")
-	since = querySinceTime
+	// the offset keeps the window correct whichever time zone the API renders times in
+	since := c.clock.Now().Add(timeRange * time.Minute).UTC().Format(time.RFC3339)
 
 	var incidentList []pagerduty.Incident
-	var offset uint = 0
-	for {
-		var options = pagerduty.ListIncidentsOptions{
-			Limit:    limit,
-			Offset:   offset,
-			Since:    since,
-			TimeZone: "UTC",
+	err = walkPages(ctx, c.paging, c.incidentPages(since), func(incident pagerduty.Incident) (bool, error) {
+		if incident.EscalationPolicy.ID == escalationPolicyID {
+			incidentList = append(incidentList, incident)
 		}
-		response, err := c.pdClient.ListIncidentsWithContext(context.Background(), options)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list incidents: %s", err)
-		}
-
-		if len(response.Incidents) < 1 {
-			break
-		}
-		for _, incident := range response.Incidents {
-			if incident.EscalationPolicy.ID == escalationPolicyID {
-				incidentList = append(incidentList, incident)
-			}
-			since = incident.CreatedAt
-		}
-
-		offset = offset + increase
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
 	}
 	return incidentList, nil
 }
@@ -342,25 +689,36 @@ func (c *client) GetIndicentsByEscalationPolicy(escalationPolicyID string, timeR
 // GetIndicentsByTag returns an array of pagerduty.Incident objects
 // assosiated with the specified tag within the given time range
 func (c *client) GetIndicentsByTag(tagName string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	return c.GetIndicentsByTagWithContext(context.Background(), tagName, timeRange)
+}
+
+// GetIndicentsByTagWithContext is GetIndicentsByTag that passes ctx down to every lookup it fans out to
+func (c *client) GetIndicentsByTagWithContext(ctx context.Context, tagName string, timeRange time.Duration) (_ []pagerduty.Incident, err error) {
+	ctx, call := c.startCall(ctx, "GetIndicentsByTag", tagName, timeRange)
+	defer call.end(&err)
+
 	if strings.TrimSpace(tagName) == "" {
-		return nil, fmt.Errorf("passed parameter 'tagName' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'tagName' must be specified", ErrInvalidArgument)
 	}
 
 	var incidentList []pagerduty.Incident
-	tags, err := c.ListAllTags(pagerduty.ListTagOptions{Query: tagName})
+	tags, err := c.ListAllTagsWithContext(ctx, pagerduty.ListTagOptions{Query: tagName})
 	if err != nil {
 		return nil, err
 	}
 	tagIdList := tags
+	if len(tagIdList) < 1 {
+		return nil, fmt.Errorf("%w: no tag matches '%s'", ErrNotFound, tagName)
+	}
 
-	epResponse, err := c.GetEscalationPoliciesByTag(tagIdList[0].ID)
+	epResponse, err := c.GetEscalationPoliciesByTagWithContext(ctx, tagIdList[0].ID)
 	if err != nil {
 		return nil, err
 	}
 	epList := epResponse.EscalationPolicies
 
 	for _, ep := range epList {
-		incidents, err := c.GetIndicentsByEscalationPolicy(ep.ID, timeRange)
+		incidents, err := c.GetIndicentsByEscalationPolicyWithContext(ctx, ep.ID, timeRange)
 		if err != nil {
 			return nil, err
 		}
@@ -371,12 +729,20 @@ func (c *client) GetIndicentsByTag(tagName string, timeRange time.Duration) ([]p
 
 // CreateIncident creates PagerDuty incident configured with input parameters
 func (c *client) CreateIncident(title, serviceID, urgency, details, escalationPolicyID string) (*pagerduty.Incident, error) {
+	return c.CreateIncidentWithContext(context.Background(), title, serviceID, urgency, details, escalationPolicyID)
+}
+
+// CreateIncidentWithContext is CreateIncident bound to ctx
+func (c *client) CreateIncidentWithContext(ctx context.Context, title, serviceID, urgency, details, escalationPolicyID string) (_ *pagerduty.Incident, err error) {
+	ctx, call := c.startCall(ctx, "CreateIncident", title, serviceID, urgency, details, escalationPolicyID)
+	defer call.end(&err)
+
 	// Only title and serviceID are required fields as per API reference
 	if strings.TrimSpace(title) == "" {
-		return nil, fmt.Errorf("passed parameter 'title' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'title' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(serviceID) == "" {
-		return nil, fmt.Errorf("passed parameter 'serviceID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'serviceID' must be specified", ErrInvalidArgument)
 	}
 
 	options := &pagerduty.CreateIncidentOptions{
@@ -404,9 +770,11 @@ func (c *client) CreateIncident(title, serviceID, urgency, details, escalationPo
 		}
 	}
 
-	incident, err := c.pdClient.CreateIncidentWithContext(context.Background(), "nobody@justin.tv", options)
+	incident, err := withRetry(ctx, c, nonIdempotent, func(ctx context.Context) (*pagerduty.Incident, error) {
+		return c.pdClient.CreateIncidentWithContext(ctx, c.config.RequesterEmail, options)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("API request to create an incident failed: %s", err)
+		return nil, fmt.Errorf("API request to create an incident failed: %w", contextError(ctx, err))
 	}
 
 	return incident, nil
@@ -415,106 +783,130 @@ func (c *client) CreateIncident(title, serviceID, urgency, details, escalationPo
 // SearchIncidents returns an array of pagerduty.Incident objects,
 // whose Incident.Service.Summary property contains a specified serviceQuery
 func (c *client) SearchIncidents(serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error) {
-	UTC, err := time.LoadLocation("UTC")
-	if err != nil {
-		return nil, fmt.Errorf("failed to load time location: %s", err)
-	}
+	return c.SearchIncidentsWithContext(context.Background(), serviceQuery, timeRange)
+}
 
-	queryTimeRange := time.Now().Add(timeRange * time.Minute).In(UTC).Format("2006-01-02T15:04:05")
-	var since = ""
-	since = queryTimeRange
+// SearchIncidentsWithContext is SearchIncidents that stops paging as soon as ctx is done
+func (c *client) SearchIncidentsWithContext(ctx context.Context, serviceQuery string, timeRange time.Duration) (_ []pagerduty.Incident, err error) {
+	ctx, call := c.startCall(ctx, "SearchIncidents", serviceQuery, timeRange)
+	defer call.end(&err)
 
-	var searchResults []pagerduty.Incident
-	var offset uint = 0
-	for {
-		var options = pagerduty.ListIncidentsOptions{
-			Limit:    limit,
-			Offset:   offset,
-			Since:    since,
-			TimeZone: "UTC",
-		}
-		response, err := c.pdClient.ListIncidentsWithContext(context.Background(), options)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list incidents: %s", err)
-		}
+	// the offset keeps the window correct whichever time zone the API renders times in
+	since := c.clock.Now().Add(timeRange * time.Minute).UTC().Format(time.RFC3339)
 
-		if len(response.Incidents) < 1 {
-			break
-		}
-		for _, incident := range response.Incidents {
-			if strings.Contains(incident.Service.Summary, serviceQuery) {
-				searchResults = append(searchResults, incident)
-			}
-			since = incident.CreatedAt
+	var searchResults []pagerduty.Incident
+	err = walkPages(ctx, c.paging, c.incidentPages(since), func(incident pagerduty.Incident) (bool, error) {
+		if strings.Contains(incident.Service.Summary, serviceQuery) {
+			searchResults = append(searchResults, incident)
 		}
-
-		offset = offset + increase
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
 	}
 
 	return searchResults, nil
 }
 
+// incidentPages fetches pages of incidents created after since
+// API documentation: https://developer.pagerduty.com/api-reference/9d0b4b12e36f9-list-incidents
+func (c *client) incidentPages(since string) pageFunc[pagerduty.Incident] {
+	return func(ctx context.Context, offset, limit uint) ([]pagerduty.Incident, pagerduty.APIListObject, error) {
+		response, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListIncidentsResponse, error) {
+			return c.pdClient.ListIncidentsWithContext(ctx, pagerduty.ListIncidentsOptions{
+				Limit:    limit,
+				Offset:   offset,
+				Since:    since,
+				TimeZone: c.config.DefaultTimeZone,
+			})
+		})
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
+		}
+		return response.Incidents, response.APIListObject, nil
+	}
+}
+
 // SearchIncidentLogs will return a nilable string that contains entries of
 // the given type that are asossiated with the specified incidentID,
 // or nil otherwise.
 func (c *client) SearchIncidentLogs(incidentID string, logType string) (*string, error) {
+	return c.SearchIncidentLogsWithContext(context.Background(), incidentID, logType)
+}
+
+// SearchIncidentLogsWithContext is SearchIncidentLogs that stops paging as soon as ctx is done
+func (c *client) SearchIncidentLogsWithContext(ctx context.Context, incidentID string, logType string) (_ *string, err error) {
+	ctx, call := c.startCall(ctx, "SearchIncidentLogs", incidentID, logType)
+	defer call.end(&err)
+
 	if strings.TrimSpace(incidentID) == "" {
-		return nil, fmt.Errorf("passed parameter 'incidentID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'incidentID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(logType) == "" {
-		return nil, fmt.Errorf("passed parameter 'logType' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'logType' must be specified", ErrInvalidArgument)
 	}
 
-	var offset uint = 0
-	for {
+	var summary *string
+	err = walkPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]pagerduty.LogEntry, pagerduty.APIListObject, error) {
 		var options = pagerduty.ListIncidentLogEntriesOptions{
 			Limit:      limit,
 			Offset:     offset,
 			IsOverview: false,
 		}
 		// API Documentation: https://developer.pagerduty.com/api-reference/367602cbc1c28-list-log-entries-for-an-incident
-		incidentLog, err := c.pdClient.ListIncidentLogEntriesWithContext(
-			context.Background(), incidentID, options)
+		incidentLog, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.ListIncidentLogEntriesResponse, error) {
+			return c.pdClient.ListIncidentLogEntriesWithContext(
+				ctx, incidentID, options)
+		})
 		if err != nil {
-			return nil, fmt.Errorf("error getting incident log: %s", err)
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
 		}
-
-		if len(incidentLog.LogEntries) < 1 {
-			break
-		}
-
-		for _, logEntry := range incidentLog.LogEntries {
-			if logEntry.Type == logType {
-				return &logEntry.Summary, nil
-			}
+		return incidentLog.LogEntries, incidentLog.APIListObject, nil
+	}, func(logEntry pagerduty.LogEntry) (bool, error) {
+		if logEntry.Type == logType {
+			summary = &logEntry.Summary
+			return false, nil
 		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting incident log: %w", err)
 	}
 
-	return nil, nil
+	return summary, nil
 }
 
 // GetUsersIDsByNames returns an array of user IDs that match specified names
 func (c *client) GetUsersIDsByNames(names []string) ([]string, error) {
+	return c.GetUsersIDsByNamesWithContext(context.Background(), names)
+}
+
+// GetUsersIDsByNamesWithContext is GetUsersIDsByNames that stops paging as soon as ctx is done
+func (c *client) GetUsersIDsByNamesWithContext(ctx context.Context, names []string) (_ []string, err error) {
+	ctx, call := c.startCall(ctx, "GetUsersIDsByNames", names)
+	defer call.end(&err)
+
 	if names == nil {
-		return nil, fmt.Errorf("array of names must be defined")
+		return nil, fmt.Errorf("%w: array of names must be defined", ErrInvalidArgument)
 	}
 	if len(names) < 1 {
-		return nil, fmt.Errorf("array of names cannot be empty")
+		return nil, fmt.Errorf("%w: array of names cannot be empty", ErrInvalidArgument)
 	}
 	for i := 0; i < len(names); i++ {
 		if len(strings.TrimSpace(names[i])) == 0 {
-			return nil, fmt.Errorf("could not get user names because the passed array had empty string(s)")
+			return nil, fmt.Errorf("%w: could not get user names because the passed array had empty string(s)", ErrInvalidArgument)
 		}
 	}
 
 	var resp []string
 	for i := range names {
-		users, err := c.ListAllUsers(
+		users, err := c.ListAllUsersWithContext(
+			ctx,
 			pagerduty.ListUsersOptions{
 				Query: names[i],
 			})
 		if err != nil {
-			return []string{}, fmt.Errorf("error getting users from PagerDuty: %s", err)
+			return []string{}, fmt.Errorf("error getting users from PagerDuty: %w", err)
 		}
 
 		for j := range users {
@@ -523,24 +915,29 @@ func (c *client) GetUsersIDsByNames(names []string) ([]string, error) {
 				break
 			}
 		}
-		if len(resp) > 0 {
-			break
-		}
 	}
 	return resp, nil
 }
 
 // UpdateEscalationPolicy updates escalation policy using the input parameters
 func (c *client) UpdateEscalationPolicy(id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	return c.UpdateEscalationPolicyWithContext(context.Background(), id, userID, serviceID, teamID, escalation)
+}
+
+// UpdateEscalationPolicyWithContext is UpdateEscalationPolicy bound to ctx
+func (c *client) UpdateEscalationPolicyWithContext(ctx context.Context, id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (_ *pagerduty.EscalationPolicy, err error) {
+	ctx, call := c.startCall(ctx, "UpdateEscalationPolicy", id, userID, serviceID, teamID, escalation)
+	defer call.end(&err)
+
 	// only id, userID, and escalation are required fields as per API reference
 	if strings.TrimSpace(id) == "" {
-		return nil, fmt.Errorf("passed parameter 'id' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(userID) == "" {
-		return nil, fmt.Errorf("passed parameter 'userID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'userID' must be specified", ErrInvalidArgument)
 	}
 	if escalation == nil {
-		return nil, fmt.Errorf("passed parameter 'escalation' cannot be nil")
+		return nil, fmt.Errorf("%w: passed parameter 'escalation' cannot be nil", ErrInvalidArgument)
 	}
 
 	escalationPolicy := pagerduty.EscalationPolicy{
@@ -579,43 +976,71 @@ func (c *client) UpdateEscalationPolicy(id, userID, serviceID, teamID string, es
 		}
 	}
 
-	newEscalationPolicy, err := c.pdClient.UpdateEscalationPolicyWithContext(context.Background(), id, escalationPolicy)
-	return newEscalationPolicy, err
+	newEscalationPolicy, err := withRetry(ctx, c, idempotent, func(ctx context.Context) (*pagerduty.EscalationPolicy, error) {
+		return c.pdClient.UpdateEscalationPolicyWithContext(ctx, id, escalationPolicy)
+	})
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return newEscalationPolicy, nil
 }
 
 // GetEscalationPoliciesByTag returns a *pagerduty.ListEPResponse object containing an array of all existing escalation policies
 func (c *client) GetEscalationPoliciesByTag(tagID string) (*pagerduty.ListEPResponse, error) {
+	return c.GetEscalationPoliciesByTagWithContext(context.Background(), tagID)
+}
+
+// GetEscalationPoliciesByTagWithContext is GetEscalationPoliciesByTag that stops paging as soon as ctx is done
+func (c *client) GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (_ *pagerduty.ListEPResponse, err error) {
+	ctx, call := c.startCall(ctx, "GetEscalationPoliciesByTag", tagID)
+	defer call.end(&err)
+
 	if strings.TrimSpace(tagID) == "" {
-		return nil, fmt.Errorf("passed parameter 'tagID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'tagID' must be specified", ErrInvalidArgument)
 	}
 
-	response, err := c.pdClient.GetEscalationPoliciesByTag(tagID)
+	// go-pagerduty only exposes this endpoint with its own pagination, so the whole
+	// result is handed to the paginator as a single page to apply the item cap
+	escalationPolicies, err := collectPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]*pagerduty.APIObject, pagerduty.APIListObject, error) {
+		escalationPolicies, err := withRetry(ctx, c, idempotent, func(ctx context.Context) ([]*pagerduty.APIObject, error) {
+			return c.pdClient.GetEscalationPoliciesByTagPaginated(ctx, tagID)
+		})
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
+		}
+		return escalationPolicies, pagerduty.APIListObject{More: false}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return response, nil
+	return &pagerduty.ListEPResponse{EscalationPolicies: escalationPolicies}, nil
 }
 
 // ListAllTags returns a *pagerduty.ListTagResponse containing all existing tags
 // API documentation: https://developer.pagerduty.com/api-reference/e44b160c69bf3-list-tags
 func (c *client) ListAllTags(options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
-	options.Limit = limit
-	options.Offset = 0
+	return c.ListAllTagsWithContext(context.Background(), options)
+}
 
-	var tags []*pagerduty.Tag
-	var offset uint = 0
-	for {
-		response, err := c.pdClient.ListTags(options)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list tags: %s", err)
-		}
+// ListAllTagsWithContext is ListAllTags that stops paging as soon as ctx is done
+func (c *client) ListAllTagsWithContext(ctx context.Context, options pagerduty.ListTagOptions) (_ []*pagerduty.Tag, err error) {
+	ctx, call := c.startCall(ctx, "ListAllTags", options)
+	defer call.end(&err)
 
-		tags = append(tags, response.Tags...)
-		if len(response.Tags) < 1 {
-			break
+	// go-pagerduty only exposes this endpoint with its own pagination, so the whole
+	// result is handed to the paginator as a single page to apply the item cap
+	tags, err := collectPages(ctx, c.paging, func(ctx context.Context, offset, limit uint) ([]*pagerduty.Tag, pagerduty.APIListObject, error) {
+		tags, err := withRetry(ctx, c, idempotent, func(ctx context.Context) ([]*pagerduty.Tag, error) {
+			return c.pdClient.ListTagsPaginated(ctx, options)
+		})
+		if err != nil {
+			return nil, pagerduty.APIListObject{}, contextError(ctx, err)
 		}
-		offset = offset + increase
+		return tags, pagerduty.APIListObject{More: false}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
 	}
 
 	return tags, nil
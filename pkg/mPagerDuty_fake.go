@@ -1,14 +1,35 @@
 package mPagerDuty
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PagerDuty/go-pagerduty"
 )
 
+// FakePDClient is an in-memory IMPagerDuty. It starts out with the resources of its fixtures,
+// and what it creates, updates and deletes is reflected by later reads of the same client
+//
+// The zero value is ready to use and starts out with DefaultFixtures. A FakePDClient must not be copied after first use
 type FakePDClient struct {
+	once   sync.Once
+	store  *fakeStore
+	faults faultInjector
+	calls  callRecorder
+	// config and observer are those of the options of GetMPagerDutyClient in ModeFake
+	config   Config
+	observer *observer
+}
+
+// NewFakePDClient returns a FakePDClient that starts out with the resources of fixtures once they are validated
+func NewFakePDClient(fixtures *Fixtures) (*FakePDClient, error) {
+	if err := fixtures.Validate(); err != nil {
+		return nil, err
+	}
+	return &FakePDClient{store: newFakeStore(fixtures)}, nil
 }
 
 const (
@@ -18,278 +39,852 @@ const (
 	existingScheduleName = "Caleb Young TESTING"
 )
 
-// GetOnCalls returns a pre-defined ListOnCallsResponse object defined in getFakedOnCalls()
+// data returns the store of the client, seeding it on first use
+func (fakeClient *FakePDClient) data() *fakeStore {
+	fakeClient.once.Do(func() {
+		if fakeClient.store == nil {
+			fakeClient.store = newFakeStore(DefaultFixtures())
+		}
+	})
+	return fakeClient.store
+}
+
+// SetClock makes the client tell the time by clock, for the windows of incident lookups
+// and the creation time of new incidents, or by SystemClock when clock is nil
+func (fakeClient *FakePDClient) SetClock(clock Clock) {
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.clock = orSystemClock(clock)
+}
+
+// GetOnCallsByScheduleIDs returns the stored on-calls of the given schedules
 // unless the array passed to the function contains no elements
 func (fakeClient *FakePDClient) GetOnCallsByScheduleIDs(scheduleIDs []string) ([]pagerduty.OnCall, error) {
+	return fakeClient.GetOnCallsByScheduleIDsWithContext(context.Background(), scheduleIDs)
+}
+
+// GetOnCallsByScheduleIDsWithContext is GetOnCallsByScheduleIDs that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetOnCallsByScheduleIDsWithContext(ctx context.Context, scheduleIDs []string) (result []pagerduty.OnCall, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetOnCallsByScheduleIDs", scheduleIDs)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if scheduleIDs == nil {
-		return nil, fmt.Errorf("array of scheduleIDs must be defined")
+		return nil, fmt.Errorf("%w: array of scheduleIDs must be defined", ErrInvalidArgument)
 	}
 
 	if len(scheduleIDs) < 1 {
-		return nil, fmt.Errorf("could not get on-calls because scheduleIDs was empty")
+		return nil, fmt.Errorf("%w: could not get on-calls because scheduleIDs was empty", ErrInvalidArgument)
 	}
 
 	for i := 0; i < len(scheduleIDs); i++ {
 		if len(strings.TrimSpace(scheduleIDs[i])) == 0 {
-			return nil, fmt.Errorf("could not get on-calls because the passed array had empty string(s)")
+			return nil, fmt.Errorf("%w: could not get on-calls because the passed array had empty string(s)", ErrInvalidArgument)
 		}
 	}
 
-	fakeResponse := getFakedOnCalls().OnCalls
-	return fakeResponse, nil
+	response, err := fakeClient.GetOnCallsWithOptionsWithContext(ctx, &pagerduty.ListOnCallOptions{ScheduleIDs: scheduleIDs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on calls: %w", err)
+	}
+	return response.OnCalls, nil
 }
 
-// GetOnCalls always returns a pre-defined ListOnCallsResponse object defined in getFakedOnCalls()
+// GetOnCallsWithOptions returns the stored on-calls matching the schedule, user and escalation policy IDs of options.
+// When options.Since or options.Until is set, the on-calls are computed from the stored escalation policies and schedules
 func (fakeClient *FakePDClient) GetOnCallsWithOptions(options *pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error) {
-	fakeResponse := getFakedOnCalls()
-	return fakeResponse, nil
+	return fakeClient.GetOnCallsWithOptionsWithContext(context.Background(), options)
 }
 
-// GetScheduleIDbyName returns a pre-defined same value if the passed argument is defined
-// Otherwise, the function returns an error
+// GetOnCallsWithOptionsWithContext is GetOnCallsWithOptions that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetOnCallsWithOptionsWithContext(ctx context.Context, options *pagerduty.ListOnCallOptions) (result *pagerduty.ListOnCallsResponse, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetOnCallsWithOptions", options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = &pagerduty.ListOnCallOptions{}
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	onCalls, err := store.listOnCalls(options.ScheduleIDs, options.UserIDs, options.EscalationPolicyIDs, options.Since, options.Until)
+	if err != nil {
+		return nil, err
+	}
+	return &pagerduty.ListOnCallsResponse{
+		APIListObject: pagerduty.APIListObject{Limit: limit, Total: uint(len(onCalls))},
+		OnCalls:       onCalls,
+	}, nil
+}
+
+// GetOnCallsAt returns who the stored escalation policies and schedules put on call
+// at every escalation level at the time at
+func (fakeClient *FakePDClient) GetOnCallsAt(scheduleIDs, escalationPolicyIDs []string, at time.Time) ([]OnCallLevel, error) {
+	return fakeClient.GetOnCallsAtWithContext(context.Background(), scheduleIDs, escalationPolicyIDs, at)
+}
+
+// GetOnCallsAtWithContext is GetOnCallsAt that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetOnCallsAtWithContext(ctx context.Context, scheduleIDs, escalationPolicyIDs []string, at time.Time) (result []OnCallLevel, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetOnCallsAt", scheduleIDs, escalationPolicyIDs, at)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateOnCallsAt(scheduleIDs, escalationPolicyIDs, at); err != nil {
+		return nil, err
+	}
+
+	options := onCallsAtOptions(scheduleIDs, escalationPolicyIDs, at, fakeClient.config.DefaultTimeZone)
+	response, err := fakeClient.GetOnCallsWithOptionsWithContext(ctx, &options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list on calls: %w", err)
+	}
+	return newOnCallLevels(response.OnCalls, at)
+}
+
+// GetScheduleIDbyName returns ID and timezone of the stored schedule with the given name,
+// or empty strings if there is none
 func (fakeClient *FakePDClient) GetScheduleIDbyName(name string) (string, string, error) {
+	return fakeClient.GetScheduleIDbyNameWithContext(context.Background(), name)
+}
+
+// GetScheduleIDbyNameWithContext is GetScheduleIDbyName that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetScheduleIDbyNameWithContext(ctx context.Context, name string) (id string, timeZone string, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetScheduleIDbyName", name)
+	defer call.end(&err, &id, &timeZone)
+	if err != nil {
+		return "", "", err
+	}
+
 	if strings.TrimSpace(name) == "" {
-		return "", "", fmt.Errorf("passed parameter 'name' must be specified")
+		return "", "", fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, schedule := range store.schedules {
+		if strings.EqualFold(schedule.Name, fakeClient.config.scheduleTitle(name)) {
+			return schedule.ID, schedule.TimeZone, nil
+		}
+	}
+	return "", "", nil
+}
+
+// ResolveSchedule returns the stored schedule that name matches best, with or without the configured schedule prefix,
+// regardless of case and diacritics, and allowing for partial names and typos
+func (fakeClient *FakePDClient) ResolveSchedule(name string) (*ScheduleMatch, error) {
+	return fakeClient.ResolveScheduleWithContext(context.Background(), name)
+}
+
+// ResolveScheduleWithContext is ResolveSchedule that fails fast once ctx is done
+func (fakeClient *FakePDClient) ResolveScheduleWithContext(ctx context.Context, name string) (result *ScheduleMatch, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "ResolveSchedule", name)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return resolveScheduleName(store.schedules, name, fakeClient.config.SchedulePrefix)
+}
+
+// GetScheduleByID returns the stored schedule with the given ID
+// Otherwise, the function returns an error
+func (fakeClient *FakePDClient) GetScheduleByID(id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	return fakeClient.GetScheduleByIDWithContext(context.Background(), id, options)
+}
+
+// GetScheduleByIDWithContext is GetScheduleByID that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (result *pagerduty.Schedule, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetScheduleByID", id, options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
 	}
-	return existingScheduleID, "America/Denver", nil
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	schedule, err := store.getSchedule(id, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pagerduty schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// GetRenderedSchedule returns who the stored schedule puts on call between since and until, in timeZone
+// or in the time zone of the schedule when timeZone is empty
+func (fakeClient *FakePDClient) GetRenderedSchedule(scheduleID string, since, until time.Time, timeZone string) (*RenderedSchedule, error) {
+	return fakeClient.GetRenderedScheduleWithContext(context.Background(), scheduleID, since, until, timeZone)
+}
+
+// GetRenderedScheduleWithContext is GetRenderedSchedule that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetRenderedScheduleWithContext(ctx context.Context, scheduleID string, since, until time.Time, timeZone string) (result *RenderedSchedule, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetRenderedSchedule", scheduleID, since, until, timeZone)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := validateRenderWindow(scheduleID, since, until, timeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := fakeClient.GetScheduleByIDWithContext(ctx, scheduleID, renderOptions(since, until, timeZone))
+	if err != nil {
+		return nil, err
+	}
+	return newRenderedSchedule(schedule, since, until, location)
+}
+
+// ListSchedules returns the stored schedules whose name contains options.Query
+// and that belong to one of options.TeamIDs, when these are set
+func (fakeClient *FakePDClient) ListSchedules(options ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	return fakeClient.ListSchedulesWithContext(context.Background(), options)
+}
+
+// ListSchedulesWithContext is ListSchedules that fails fast once ctx is done
+func (fakeClient *FakePDClient) ListSchedulesWithContext(ctx context.Context, options ListSchedulesOptions) (result []pagerduty.Schedule, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "ListSchedules", options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	schedules := []pagerduty.Schedule{}
+	for _, schedule := range store.listSchedules(options.Query) {
+		if scheduleInTeams(schedule, options.TeamIDs) {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
 }
 
-// GetUserIDbyName returns a pre-defined same value if the passed argument is defined
+// CreateSchedule stores and returns a new schedule if it has a time zone and at least one layer
 // Otherwise, the function returns an error
+func (fakeClient *FakePDClient) CreateSchedule(schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return fakeClient.CreateScheduleWithContext(context.Background(), schedule)
+}
+
+// CreateScheduleWithContext is CreateSchedule that fails fast once ctx is done
+func (fakeClient *FakePDClient) CreateScheduleWithContext(ctx context.Context, schedule pagerduty.Schedule) (result *pagerduty.Schedule, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "CreateSchedule", schedule)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	created, err := store.createSchedule(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating schedule on PagerDuty: %w", err)
+	}
+	return &created, nil
+}
+
+// UpdateSchedule replaces the stored schedule with schedule and returns it,
+// if 'id' is defined and schedule has a time zone and at least one layer
+// Otherwise, the function returns an error
+func (fakeClient *FakePDClient) UpdateSchedule(id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	return fakeClient.UpdateScheduleWithContext(context.Background(), id, schedule)
+}
+
+// UpdateScheduleWithContext is UpdateSchedule that fails fast once ctx is done
+func (fakeClient *FakePDClient) UpdateScheduleWithContext(ctx context.Context, id string, schedule pagerduty.Schedule) (result *pagerduty.Schedule, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "UpdateSchedule", id, schedule)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
+	}
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	updated, err := store.updateSchedule(id, schedule)
+	if err != nil {
+		return nil, fmt.Errorf("error while updating schedule on PagerDuty: %w", err)
+	}
+	return &updated, nil
+}
+
+// DeleteSchedule deletes the stored schedule along with its overrides and on-calls, if 'id' is defined
+// Otherwise, the function returns an error
+func (fakeClient *FakePDClient) DeleteSchedule(id string) error {
+	return fakeClient.DeleteScheduleWithContext(context.Background(), id)
+}
+
+// DeleteScheduleWithContext is DeleteSchedule that fails fast once ctx is done
+func (fakeClient *FakePDClient) DeleteScheduleWithContext(ctx context.Context, id string) (err error) {
+	ctx, call, err := fakeClient.enter(ctx, "DeleteSchedule", id)
+	defer call.end(&err)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if err := store.deleteSchedule(id); err != nil {
+		return fmt.Errorf("error while deleting schedule on PagerDuty: %w", err)
+	}
+	return nil
+}
+
+// GetUserIDbyName returns ID of the stored user with the given name, or an empty string if there is none
 func (fakeClient *FakePDClient) GetUserIDbyName(name string) (string, error) {
+	return fakeClient.GetUserIDbyNameWithContext(context.Background(), name)
+}
+
+// GetUserIDbyNameWithContext is GetUserIDbyName that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetUserIDbyNameWithContext(ctx context.Context, name string) (result string, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetUserIDbyName", name)
+	defer call.end(&err, &result)
+	if err != nil {
+		return "", err
+	}
+
 	if strings.TrimSpace(name) == "" {
-		return "", fmt.Errorf("passed parameter 'name' must be specified")
+		return "", fmt.Errorf("%w: passed parameter 'name' must be specified", ErrInvalidArgument)
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for _, user := range store.users {
+		if len(fakeClient.config.TeamIDs) > 0 && !userInTeams(user, fakeClient.config.TeamIDs) {
+			continue
+		}
+		normalizedName, err := normalizeString(user.Name)
+		if err != nil {
+			return "", fmt.Errorf("error while normalizing user name '%s': %w", user.Name, err)
+		}
+		if strings.EqualFold(normalizedName, name) {
+			return user.ID, nil
+		}
 	}
-	return existingUserID, nil
+	return "", nil
 }
 
-// GetUserByID returns a pre-defined pagerduty.User object if the passed 'id' parameter is defined
+// GetUserByID returns the stored user with the given ID
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) GetUserByID(id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	return fakeClient.GetUserByIDWithContext(context.Background(), id, options)
+}
+
+// GetUserByIDWithContext is GetUserByID that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (result *pagerduty.User, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetUserByID", id, options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(id) == "" {
-		return nil, fmt.Errorf("passed parameter 'id' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
 	}
 
-	user := getFakedUser()
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	i, ok := store.findUser(id)
+	if !ok {
+		return nil, fmt.Errorf("failed to get pagerduty user: %w", notFoundError("User"))
+	}
+	user := deepCopy(store.users[i])
 	return &user, nil
 }
 
-// ListAllUsers returns an array of three same pre-defined pagerduty.User objects
+// ListAllUsers returns the stored users whose name or email contain options.Query
+// and who belong to one of options.TeamIDs, when these are set
 func (fakeClient *FakePDClient) ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
-	users := []pagerduty.User{getFakedUser(), getFakedUser(), getFakedUser()}
+	return fakeClient.ListAllUsersWithContext(context.Background(), options)
+}
 
-	return users, nil
+// ListAllUsersWithContext is ListAllUsers that fails fast once ctx is done
+func (fakeClient *FakePDClient) ListAllUsersWithContext(ctx context.Context, options pagerduty.ListUsersOptions) (result []pagerduty.User, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "ListAllUsers", options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.listUsers(options.Query, options.TeamIDs), nil
 }
 
-// GetOverrides returns a pagerduty.ListOverridesResponse containing an array
-// of three different pre-defined pagerduty.Override objects, if all passed parameters are defined
+// GetOverrides returns the stored overrides of the schedule that overlap the range between since and until,
+// truncated to the range unless includeOverflow is set, if all passed parameters are defined
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) GetOverrides(scheduleID string, since string, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error) {
+	return fakeClient.GetOverridesWithContext(context.Background(), scheduleID, since, until, includeOverflow)
+}
+
+// GetOverridesWithContext is GetOverrides that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetOverridesWithContext(ctx context.Context, scheduleID string, since string, until string, includeOverflow bool) (result *pagerduty.ListOverridesResponse, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetOverrides", scheduleID, since, until, includeOverflow)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return nil, fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 
 	if strings.TrimSpace(since) == "" || strings.TrimSpace(until) == "" {
-		return nil, fmt.Errorf("passed parameters 'since' and 'until' dates must be specified")
+		return nil, fmt.Errorf("%w: passed parameters 'since' and 'until' dates must be specified", ErrInvalidArgument)
 	}
 
-	overrides := getFakedOverridesList()
-	return &overrides, nil
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	overrides, err := store.listOverrides(scheduleID, since, until, includeOverflow)
+	if err != nil {
+		return nil, err
+	}
+	return &pagerduty.ListOverridesResponse{Overrides: overrides}, nil
 }
 
-// CreateOverride will return nil and a faked *pagerduty.Override object
+// CreateOverride stores and returns a new override of the stored schedule and user
 // if all passed parameters are defined
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) CreateOverride(scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	return fakeClient.CreateOverrideWithContext(context.Background(), scheduleID, userID, start, end)
+}
+
+// CreateOverrideWithContext is CreateOverride that fails fast once ctx is done
+func (fakeClient *FakePDClient) CreateOverrideWithContext(ctx context.Context, scheduleID string, userID string, start string, end string) (result *pagerduty.Override, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "CreateOverride", scheduleID, userID, start, end)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return nil, fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(userID) == "" {
-		return nil, fmt.Errorf("passed parameter 'userID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'userID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(start) == "" {
-		return nil, fmt.Errorf("passed parameter 'start' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'start' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(end) == "" {
-		return nil, fmt.Errorf("passed parameter 'end' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'end' must be specified", ErrInvalidArgument)
 	}
-	return &pagerduty.Override{
-		ID:    "TEST",
-		Start: start,
-		End:   end,
-		User:  pagerduty.APIObject{ID: userID},
-	}, nil
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	override, err := store.createOverride(scheduleID, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating override on PagerDuty: %w", err)
+	}
+	return &override, nil
 }
 
-// RemoveOverride will return nil if all passed parameters are defined
+// RemoveOverride deletes the stored override if all passed parameters are defined
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) RemoveOverride(scheduleID string, overrideID string) error {
+	return fakeClient.RemoveOverrideWithContext(context.Background(), scheduleID, overrideID)
+}
+
+// RemoveOverrideWithContext is RemoveOverride that fails fast once ctx is done
+func (fakeClient *FakePDClient) RemoveOverrideWithContext(ctx context.Context, scheduleID string, overrideID string) (err error) {
+	ctx, call, err := fakeClient.enter(ctx, "RemoveOverride", scheduleID, overrideID)
+	defer call.end(&err)
+	if err != nil {
+		return err
+	}
+
 	if strings.TrimSpace(scheduleID) == "" {
-		return fmt.Errorf("passed parameter 'scheduleID' must be specified")
+		return fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(overrideID) == "" {
-		return fmt.Errorf("passed parameter 'overrideID' must be specified")
+		return fmt.Errorf("%w: passed parameter 'overrideID' must be specified", ErrInvalidArgument)
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if err := store.removeOverride(scheduleID, overrideID); err != nil {
+		return fmt.Errorf("error while removing override on PagerDuty: %w", err)
 	}
 	return nil
 }
 
-// GetIndicentsByTag returns faked pre-defined array of pagerduty.Incident objects
+// GetIndicentsByTag returns the stored incidents of the escalation policies
+// assigned to the tag matching tagName within the given time range
 func (fakeClient *FakePDClient) GetIndicentsByTag(tagName string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	return fakeClient.GetIndicentsByTagWithContext(context.Background(), tagName, timeRange)
+}
+
+// GetIndicentsByTagWithContext is GetIndicentsByTag that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetIndicentsByTagWithContext(ctx context.Context, tagName string, timeRange time.Duration) (result []pagerduty.Incident, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetIndicentsByTag", tagName, timeRange)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(tagName) == "" {
-		return nil, fmt.Errorf("passed parameter 'tagName' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'tagName' must be specified", ErrInvalidArgument)
 	}
 
-	incidentList := getFakedIncidents()
+	tags, err := fakeClient.ListAllTagsWithContext(ctx, pagerduty.ListTagOptions{Query: tagName})
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) < 1 {
+		return nil, fmt.Errorf("%w: no tag matches '%s'", ErrNotFound, tagName)
+	}
+
+	epResponse, err := fakeClient.GetEscalationPoliciesByTagWithContext(ctx, tags[0].ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var incidentList []pagerduty.Incident
+	for _, ep := range epResponse.EscalationPolicies {
+		incidents, err := fakeClient.GetIndicentsByEscalationPolicyWithContext(ctx, ep.ID, timeRange)
+		if err != nil {
+			return nil, err
+		}
+		incidentList = append(incidentList, incidents...)
+	}
 	return incidentList, nil
 }
 
-// CreateIncident will return a faked *pagerduty.Incident object
+// CreateIncident stores and returns a new triggered incident
 // if 'title' and 'serviceID' are defined.
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) CreateIncident(title, serviceID, urgency, details, escalationPolicyID string) (*pagerduty.Incident, error) {
+	return fakeClient.CreateIncidentWithContext(context.Background(), title, serviceID, urgency, details, escalationPolicyID)
+}
+
+// CreateIncidentWithContext is CreateIncident that fails fast once ctx is done
+func (fakeClient *FakePDClient) CreateIncidentWithContext(ctx context.Context, title, serviceID, urgency, details, escalationPolicyID string) (result *pagerduty.Incident, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "CreateIncident", title, serviceID, urgency, details, escalationPolicyID)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	// Only title and serviceID are required fields as per API reference
 	if strings.TrimSpace(title) == "" {
-		return nil, fmt.Errorf("passed parameter 'title' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'title' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(serviceID) == "" {
-		return nil, fmt.Errorf("passed parameter 'serviceID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'serviceID' must be specified", ErrInvalidArgument)
 	}
-	return &pagerduty.Incident{
-		Title:            title,
-		Service:          pagerduty.APIObject{ID: serviceID},
-		Urgency:          urgency,
-		EscalationPolicy: pagerduty.APIObject{ID: escalationPolicyID},
-	}, nil
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	incident := store.createIncident(title, serviceID, urgency, escalationPolicyID)
+	return &incident, nil
 }
 
-// SearchIncidents will imitate searching and finding pagerduty.Incident objects
-// by concatenating the value of serviceQuery with each element's Incident.Service.Summary property, if the parameter is defined.
-// Otherwise, if serviceQuery is not defined, the function will return an empty array
+// SearchIncidents returns the stored incidents within the given time range
+// whose Incident.Service.Summary property contains serviceQuery
 func (fakeClient *FakePDClient) SearchIncidents(serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error) {
-	if serviceQuery == "" {
-		return []pagerduty.Incident{}, nil
+	return fakeClient.SearchIncidentsWithContext(context.Background(), serviceQuery, timeRange)
+}
+
+// SearchIncidentsWithContext is SearchIncidents that fails fast once ctx is done
+func (fakeClient *FakePDClient) SearchIncidentsWithContext(ctx context.Context, serviceQuery string, timeRange time.Duration) (result []pagerduty.Incident, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "SearchIncidents", serviceQuery, timeRange)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
 	}
 
-	incidents := getFakedIncidents()
-	for _, incident := range incidents {
-		incident.Service.Summary += serviceQuery
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	since := store.clock.Now().Add(timeRange * time.Minute)
+
+	var searchResults []pagerduty.Incident
+	for _, incident := range store.listIncidents(since, time.Time{}) {
+		if strings.Contains(incident.Service.Summary, serviceQuery) {
+			searchResults = append(searchResults, incident)
+		}
 	}
-	return incidents, nil
+	return searchResults, nil
 }
 
-// SearchIncidentLogs will return an error if the passed parameters are not specified and
-// will return a faked pre-defined summary if the given logType is found in pre-defined list.
+// SearchIncidentLogs will return an error if the passed parameters are not specified or the incident is not stored,
+// and will return the summary of the first stored log entry of the incident with the given logType.
 // Otherwise, the function will return nil
 func (fakeClient *FakePDClient) SearchIncidentLogs(incidentID string, logType string) (*string, error) {
+	return fakeClient.SearchIncidentLogsWithContext(context.Background(), incidentID, logType)
+}
+
+// SearchIncidentLogsWithContext is SearchIncidentLogs that fails fast once ctx is done
+func (fakeClient *FakePDClient) SearchIncidentLogsWithContext(ctx context.Context, incidentID string, logType string) (result *string, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "SearchIncidentLogs", incidentID, logType)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(incidentID) == "" {
-		return nil, fmt.Errorf("passed parameter 'incidentID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'incidentID' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(logType) == "" {
-		return nil, fmt.Errorf("passed parameter 'logType' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'logType' must be specified", ErrInvalidArgument)
 	}
 
-	if logType == "resolve_log_entry" {
-		summary := "Resolved through the API."
-		return &summary, nil
-	}
-	if logType == "assign_log_entry" {
-		summary := "Assigned to Timur Kalandarov."
-		return &summary, nil
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	logEntries, err := store.listLogEntries(incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting incident log: %w", err)
 	}
-	if logType == "trigger_log_entry" {
-		summary := "Triggered through the API."
-		return &summary, nil
+	for _, logEntry := range logEntries {
+		if logEntry.Type == logType {
+			summary := logEntry.Summary
+			return &summary, nil
+		}
 	}
 	return nil, nil
 }
 
-// GetIndicentsByEscalationPolicy returns faked pre-defined array of pagerduty.Incident objects
+// GetIndicentsByEscalationPolicy returns the stored incidents of the escalation policy within the given time range
 func (fakeClient *FakePDClient) GetIndicentsByEscalationPolicy(escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	return fakeClient.GetIndicentsByEscalationPolicyWithContext(context.Background(), escalationPolicyID, timeRange)
+}
+
+// GetIndicentsByEscalationPolicyWithContext is GetIndicentsByEscalationPolicy that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetIndicentsByEscalationPolicyWithContext(ctx context.Context, escalationPolicyID string, timeRange time.Duration) (result []pagerduty.Incident, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetIndicentsByEscalationPolicy", escalationPolicyID, timeRange)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if strings.TrimSpace(escalationPolicyID) == "" {
-		return nil, fmt.Errorf("passed parameter 'escalationPolicyID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'escalationPolicyID' must be specified", ErrInvalidArgument)
 	}
 
-	incidentList := getFakedIncidents()
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	since := store.clock.Now().Add(timeRange * time.Minute)
 
+	var incidentList []pagerduty.Incident
+	for _, incident := range store.listIncidents(since, time.Time{}) {
+		if incident.EscalationPolicy.ID == escalationPolicyID {
+			incidentList = append(incidentList, incident)
+		}
+	}
 	return incidentList, nil
 }
 
-// GetOnCallUsersIDs will return an array of N same pre-defied values
-// where N is length of the array of names passed as a parameter
+// GetUsersIDsByNames returns IDs of the stored users with the given names, skipping names no user has
 //
 // If the parameter is nil or contains no elements, the function will return an error
 func (fakeClient *FakePDClient) GetUsersIDsByNames(names []string) ([]string, error) {
+	return fakeClient.GetUsersIDsByNamesWithContext(context.Background(), names)
+}
+
+// GetUsersIDsByNamesWithContext is GetUsersIDsByNames that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetUsersIDsByNamesWithContext(ctx context.Context, names []string) (result []string, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetUsersIDsByNames", names)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	if names == nil {
-		return nil, fmt.Errorf("array of names must be defined")
+		return nil, fmt.Errorf("%w: array of names must be defined", ErrInvalidArgument)
 	}
 	if len(names) < 1 {
-		return nil, fmt.Errorf("array of names cannot be empty")
+		return nil, fmt.Errorf("%w: array of names cannot be empty", ErrInvalidArgument)
 	}
 	for i := 0; i < len(names); i++ {
 		if len(strings.TrimSpace(names[i])) == 0 {
-			return nil, fmt.Errorf("could not get user names because the passed array had empty string(s)")
+			return nil, fmt.Errorf("%w: could not get user names because the passed array had empty string(s)", ErrInvalidArgument)
 		}
 	}
 
 	var resp []string
-	for i := 0; i < len(names); i++ {
-		resp = append(resp, existingUserID)
-	}
+	for i := range names {
+		users, err := fakeClient.ListAllUsersWithContext(ctx, pagerduty.ListUsersOptions{Query: names[i]})
+		if err != nil {
+			return []string{}, fmt.Errorf("error getting users from PagerDuty: %w", err)
+		}
 
+		for j := range users {
+			if strings.EqualFold(users[j].Name, names[i]) {
+				resp = append(resp, users[j].ID)
+				break
+			}
+		}
+	}
 	return resp, nil
 }
 
-// UpdateEscalationPolicy will return a faked *pagerduty.EscalationPolicy
-// if 'id', 'userID', and 'escalation' parameters passed are defined.
+// UpdateEscalationPolicy replaces the rules, services and teams of the stored escalation policy
+// and returns it, if 'id', 'userID', and 'escalation' parameters passed are defined.
 // Otherwise, the function returns an error
 func (fakeClient *FakePDClient) UpdateEscalationPolicy(id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	return fakeClient.UpdateEscalationPolicyWithContext(context.Background(), id, userID, serviceID, teamID, escalation)
+}
+
+// UpdateEscalationPolicyWithContext is UpdateEscalationPolicy that fails fast once ctx is done
+func (fakeClient *FakePDClient) UpdateEscalationPolicyWithContext(ctx context.Context, id, userID, serviceID, teamID string, escalation []pagerduty.APIObject) (result *pagerduty.EscalationPolicy, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "UpdateEscalationPolicy", id, userID, serviceID, teamID, escalation)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
 	// only id, userID, and escalation are required fields as per API reference
 	if strings.TrimSpace(id) == "" {
-		return nil, fmt.Errorf("passed parameter 'id' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'id' must be specified", ErrInvalidArgument)
 	}
 	if strings.TrimSpace(userID) == "" {
-		return nil, fmt.Errorf("passed parameter 'userID' must be specified")
+		return nil, fmt.Errorf("%w: passed parameter 'userID' must be specified", ErrInvalidArgument)
 	}
 	if escalation == nil {
-		return nil, fmt.Errorf("passed parameter 'escalation' cannot be nil")
+		return nil, fmt.Errorf("%w: passed parameter 'escalation' cannot be nil", ErrInvalidArgument)
 	}
 
-	return &pagerduty.EscalationPolicy{
-		APIObject: pagerduty.APIObject{ID: id},
-		Services:  []pagerduty.APIObject{{ID: serviceID}},
-		Teams:     []pagerduty.APIReference{{ID: teamID}},
-	}, nil
+	update := pagerduty.EscalationPolicy{
+		EscalationRules: []pagerduty.EscalationRule{
+			{
+				Delay:   5,
+				Targets: []pagerduty.APIObject{{ID: userID, Type: "user_reference"}},
+			},
+			{
+				Delay:   15,
+				Targets: escalation,
+			},
+		},
+	}
+	if len(serviceID) > 0 {
+		update.Services = []pagerduty.APIObject{{ID: serviceID, Type: "service_reference"}}
+	}
+	if len(teamID) > 0 {
+		update.Teams = []pagerduty.APIReference{{ID: teamID, Type: "team_reference"}}
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	policy, err := store.updateEscalationPolicy(id, update)
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
 }
 
 // GetEscalationPoliciesByTag returns a *pagerduty.ListEPResponse object
-// containing an array of pre-defined escalation policies
+// containing references to the stored escalation policies the tag is assigned to
 func (fakeClient *FakePDClient) GetEscalationPoliciesByTag(tagID string) (*pagerduty.ListEPResponse, error) {
-	if strings.TrimSpace(tagID) == "" {
-		return nil, fmt.Errorf("passed parameter 'tagID' must be specified")
+	return fakeClient.GetEscalationPoliciesByTagWithContext(context.Background(), tagID)
+}
+
+// GetEscalationPoliciesByTagWithContext is GetEscalationPoliciesByTag that fails fast once ctx is done
+func (fakeClient *FakePDClient) GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (result *pagerduty.ListEPResponse, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "GetEscalationPoliciesByTag", tagID)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
 	}
 
-	response := pagerduty.ListEPResponse{
-		EscalationPolicies: []*pagerduty.APIObject{
-			{
-				ID:      "PP2PMMD",
-				Summary: "Ad Server Escalation",
-			},
-			{
-				ID:      "PKR3E6F",
-				Summary: "App code validation",
-			},
-			{
-				ID:      "PGPQHZF",
-				Summary: "Browser Grid",
-			},
-		},
+	if strings.TrimSpace(tagID) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'tagID' must be specified", ErrInvalidArgument)
 	}
 
-	return &response, nil
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	escalationPolicies, err := store.listTaggedEscalationPolicies(tagID)
+	if err != nil {
+		return nil, err
+	}
+	return &pagerduty.ListEPResponse{EscalationPolicies: escalationPolicies}, nil
 }
 
-// ListAllTags returns a *pagerduty.ListTagResponse object containing an array of pre-defined pagerduty.Tag objects
+// ListAllTags returns the stored tags whose label contains options.Query
 func (fakeClient *FakePDClient) ListAllTags(options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
-	response := getFakedTags()
-	return response.Tags, nil
+	return fakeClient.ListAllTagsWithContext(context.Background(), options)
+}
+
+// ListAllTagsWithContext is ListAllTags that fails fast once ctx is done
+func (fakeClient *FakePDClient) ListAllTagsWithContext(ctx context.Context, options pagerduty.ListTagOptions) (result []*pagerduty.Tag, err error) {
+	ctx, call, err := fakeClient.enter(ctx, "ListAllTags", options)
+	defer call.end(&err, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	store := fakeClient.data()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.listTags(options.Query), nil
 }
@@ -0,0 +1,224 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds in seconds of the latency histograms of NewMetrics
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics is a Hook that counts the calls, HTTP requests, retries and pages of the clients
+// it is attached to, and measures their latency. Handler exposes them for scraping
+// in the OpenMetrics text format
+type Metrics struct {
+	mu              sync.Mutex
+	methodCalls     *counterVec
+	methodDuration  *histogramVec
+	requests        *counterVec
+	requestDuration *histogramVec
+	retries         *counterVec
+	pages           *counterVec
+}
+
+// NewMetrics returns an empty Metrics with latency histograms bucketed by buckets,
+// DefaultLatencyBuckets when none are given
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Metrics{
+		methodCalls:     newCounterVec("mpagerduty_method_calls", "IMPagerDuty method calls by result.", "method", "result"),
+		methodDuration:  newHistogramVec("mpagerduty_method_duration_seconds", "Latency of IMPagerDuty method calls.", buckets, "method"),
+		requests:        newCounterVec("mpagerduty_http_requests", "HTTP requests sent to PagerDuty by status code.", "method", "status"),
+		requestDuration: newHistogramVec("mpagerduty_http_request_duration_seconds", "Latency of HTTP requests sent to PagerDuty.", buckets, "method"),
+		retries:         newCounterVec("mpagerduty_retries", "Retries of failed HTTP requests.", "method"),
+		pages:           newCounterVec("mpagerduty_pages", "Pages fetched by paginated methods.", "method"),
+	}
+}
+
+// WithMetrics makes the client record its calls in metrics
+func WithMetrics(metrics *Metrics) ClientOption {
+	return WithHooks(metrics)
+}
+
+// HandleEvent records event
+func (m *Metrics) HandleEvent(ctx context.Context, event Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch event.Kind {
+	case EventMethodEnd:
+		result := "success"
+		if event.Err != nil {
+			result = "error"
+		}
+		m.methodCalls.inc(event.Method, result)
+		m.methodDuration.observe(event.Latency, event.Method)
+	case EventRequest:
+		status := "error"
+		if event.StatusCode > 0 {
+			status = strconv.Itoa(event.StatusCode)
+		}
+		m.requests.inc(event.Method, status)
+		m.requestDuration.observe(event.Latency, event.Method)
+		if event.Page > 0 && event.Err == nil && event.StatusCode < 300 {
+			m.pages.inc(event.Method)
+		}
+	case EventRetry:
+		m.retries.inc(event.Method)
+	}
+}
+
+// Handler serves the metrics in the OpenMetrics text format
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		_ = m.Export(w)
+	})
+}
+
+// Export writes the metrics to w in the OpenMetrics text format
+func (m *Metrics) Export(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	m.methodCalls.write(&b)
+	m.methodDuration.write(&b)
+	m.requests.write(&b)
+	m.requestDuration.write(&b)
+	m.retries.write(&b)
+	m.pages.write(&b)
+	b.WriteString("# EOF\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// counterVec is a family of counters partitioned by labels
+type counterVec struct {
+	name       string
+	help       string
+	labelNames []string
+	values     map[string]uint64
+}
+
+func newCounterVec(name, help string, labelNames ...string) *counterVec {
+	return &counterVec{name: name, help: help, labelNames: labelNames, values: map[string]uint64{}}
+}
+
+func (v *counterVec) inc(labelValues ...string) {
+	v.values[labelKey(labelValues)]++
+}
+
+func (v *counterVec) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# TYPE %s counter\n# HELP %s %s\n", v.name, v.name, v.help)
+	for _, key := range sortedKeys(v.values) {
+		fmt.Fprintf(b, "%s_total%s %d\n", v.name, formatLabels(v.labelNames, key), v.values[key])
+	}
+}
+
+// histogramVec is a family of histograms partitioned by labels
+type histogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+	values     map[string]*histogram
+}
+
+type histogram struct {
+	// counts holds the number of observations in each bucket, cumulated when written
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogramVec(name, help string, buckets []float64, labelNames ...string) *histogramVec {
+	return &histogramVec{name: name, help: help, labelNames: labelNames, buckets: buckets, values: map[string]*histogram{}}
+}
+
+func (v *histogramVec) observe(latency time.Duration, labelValues ...string) {
+	key := labelKey(labelValues)
+	h, ok := v.values[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(v.buckets))}
+		v.values[key] = h
+	}
+
+	seconds := latency.Seconds()
+	for i, bound := range v.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (v *histogramVec) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# TYPE %s histogram\n# HELP %s %s\n", v.name, v.name, v.help)
+	bucketLabelNames := append(append([]string(nil), v.labelNames...), "le")
+	for _, key := range sortedKeys(v.values) {
+		h := v.values[key]
+		var cumulative uint64
+		for i, bound := range v.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "%s_bucket%s %d\n", v.name, formatLabels(bucketLabelNames, key+labelSeparator+formatFloat(bound)), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket%s %d\n", v.name, formatLabels(bucketLabelNames, key+labelSeparator+"+Inf"), h.count)
+		fmt.Fprintf(b, "%s_sum%s %s\n", v.name, formatLabels(v.labelNames, key), formatFloat(h.sum))
+		fmt.Fprintf(b, "%s_count%s %d\n", v.name, formatLabels(v.labelNames, key), h.count)
+	}
+}
+
+// labelSeparator cannot appear in label values, which are method names and status codes
+const labelSeparator = "\xff"
+
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, labelSeparator)
+}
+
+func formatLabels(labelNames []string, key string) string {
+	labelValues := strings.Split(key, labelSeparator)
+	pairs := make([]string, len(labelNames))
+	for i, name := range labelNames {
+		pairs[i] = fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labelValues[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatFloat formats value canonically for OpenMetrics, which spells whole numbers such as bucket bounds as 1.0
+func formatFloat(value float64) string {
+	formatted := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(formatted, "e.") && !math.IsInf(value, 0) && !math.IsNaN(value) {
+		formatted += ".0"
+	}
+	return formatted
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,265 @@
+// Command gen writes the methods of MockPDClient, one for every method of the IMPagerDuty interface.
+// It is run by go generate in the mock package
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = `// Code generated by go run ./gen; DO NOT EDIT.
+
+package mock
+
+`
+
+func main() {
+	source := flag.String("interface", "../mPagerDuty.go", "file declaring the interface")
+	name := flag.String("name", "IMPagerDuty", "name of the interface")
+	importPath := flag.String("package", "mpagerduty/pkg", "import path of the package declaring the interface")
+	output := flag.String("output", "mpagerduty_mock.go", "file to write the mock to")
+	flag.Parse()
+
+	code, err := generate(*source, *name, *importPath)
+	if err != nil {
+		log.Fatalf("failed to generate the mock of %s: %v", *name, err)
+	}
+	if err := os.WriteFile(*output, code, 0o644); err != nil {
+		log.Fatalf("failed to write the mock of %s: %v", *name, err)
+	}
+}
+
+// generate returns the formatted source of MockPDClient for the interface called name in the file source
+func generate(source, name, importPath string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	iface, err := findInterface(file, name)
+	if err != nil {
+		return nil, err
+	}
+
+	packageName := file.Name.Name
+	imports := map[string]string{packageName: importPath}
+	var methods bytes.Buffer
+	for _, field := range iface.Methods.List {
+		funcType, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return nil, fmt.Errorf("%s embeds another interface, which the generator does not support", name)
+		}
+		for _, method := range field.Names {
+			if err := writeMethod(&methods, fset, packageName, method.Name, funcType); err != nil {
+				return nil, err
+			}
+		}
+		if err := collectImports(file, funcType, imports); err != nil {
+			return nil, err
+		}
+	}
+
+	var code bytes.Buffer
+	code.WriteString(header)
+	writeImports(&code, imports, packageName)
+	fmt.Fprintf(&code, `// MockPDClient is an %[2]s that answers the calls its Mock expects
+type MockPDClient struct {
+	*Mock
+}
+
+// MockPDClient fails to compile when it does not implement every method of %[2]s
+var _ %[1]s.%[2]s = (*MockPDClient)(nil)
+
+// NewMockPDClient returns a MockPDClient that reports to t, and asserts its expectations when the test ends
+func NewMockPDClient(t TestingT) *MockPDClient {
+	return &MockPDClient{Mock: newMock(t)}
+}
+`, packageName, name)
+	code.Write(methods.Bytes())
+
+	return format.Source(code.Bytes())
+}
+
+func findInterface(file *ast.File, name string) (*ast.InterfaceType, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != name {
+				continue
+			}
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s is not an interface", name)
+			}
+			return iface, nil
+		}
+	}
+	return nil, fmt.Errorf("no interface %s declared", name)
+}
+
+// writeMethod writes the MockPDClient method that answers calls of the interface method name.
+// WithContext variants are recorded under the name of the method they vary, without their context
+func writeMethod(w *bytes.Buffer, fset *token.FileSet, packageName, name string, funcType *ast.FuncType) error {
+	var params, args []string
+	index := 0
+	for _, field := range funcType.Params.List {
+		typ, err := typeString(fset, qualify(field.Type, packageName))
+		if err != nil {
+			return err
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", index))}
+		}
+		for _, paramName := range names {
+			params = append(params, paramName.Name+" "+typ)
+			if typ != "context.Context" {
+				args = append(args, paramName.Name)
+			}
+			index++
+		}
+	}
+
+	var results []string
+	if funcType.Results != nil {
+		for _, field := range funcType.Results.List {
+			typ, err := typeString(fset, qualify(field.Type, packageName))
+			if err != nil {
+				return err
+			}
+			results = append(results, typ)
+			for i := 1; i < len(field.Names); i++ {
+				results = append(results, typ)
+			}
+		}
+	}
+
+	recorded := strings.TrimSuffix(name, "WithContext")
+	fmt.Fprintf(w, "\nfunc (m *MockPDClient) %s(%s) (%s) {\n", name, strings.Join(params, ", "), strings.Join(results, ", "))
+	w.WriteString("\tm.t.Helper()\n")
+	callArgs := append([]string{strconv.Quote(recorded), strconv.Itoa(len(results))}, args...)
+	fmt.Fprintf(w, "\tvalues := m.called(%s)\n", strings.Join(callArgs, ", "))
+	returns := make([]string, len(results))
+	for i, typ := range results {
+		returns[i] = fmt.Sprintf("result[%s](m.Mock, %q, values, %d)", typ, recorded, i)
+	}
+	fmt.Fprintf(w, "\treturn %s\n}\n", strings.Join(returns, ", "))
+	return nil
+}
+
+// qualify returns expr with the types the package of the interface declares qualified by packageName,
+// since the mock is declared in a package of its own
+func qualify(expr ast.Expr, packageName string) ast.Expr {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if !expr.IsExported() {
+			return expr
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(packageName), Sel: ast.NewIdent(expr.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(expr.X, packageName)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: expr.Len, Elt: qualify(expr.Elt, packageName)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(expr.Key, packageName), Value: qualify(expr.Value, packageName)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualify(expr.Elt, packageName)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: expr.Dir, Value: qualify(expr.Value, packageName)}
+	}
+	return expr
+}
+
+func typeString(fset *token.FileSet, expr ast.Expr) (string, error) {
+	var typ bytes.Buffer
+	if err := printer.Fprint(&typ, fset, expr); err != nil {
+		return "", err
+	}
+	return typ.String(), nil
+}
+
+// collectImports adds the import paths of the packages the method refers to
+func collectImports(file *ast.File, funcType *ast.FuncType, imports map[string]string) error {
+	var err error
+	ast.Inspect(funcType, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		path, found := importPath(file, pkg.Name)
+		if !found {
+			err = fmt.Errorf("no import of package %s", pkg.Name)
+			return false
+		}
+		imports[pkg.Name] = path
+		return true
+	})
+	return err
+}
+
+func importPath(file *ast.File, name string) (string, bool) {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return path, true
+			}
+			continue
+		}
+		base := path[strings.LastIndex(path, "/")+1:]
+		if base == name || strings.TrimPrefix(base, "go-") == name {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// writeImports writes the import declaration, the standard library first, naming the package of the interface explicitly
+func writeImports(w *bytes.Buffer, imports map[string]string, packageName string) {
+	var standard, others []string
+	for name, path := range imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") || name == packageName {
+			others = append(others, name)
+		} else {
+			standard = append(standard, name)
+		}
+	}
+
+	w.WriteString("import (\n")
+	for i, names := range [][]string{standard, others} {
+		sort.Slice(names, func(i, j int) bool { return imports[names[i]] < imports[names[j]] })
+		if i > 0 && len(standard) > 0 {
+			w.WriteString("\n")
+		}
+		for _, name := range names {
+			if name == packageName {
+				fmt.Fprintf(w, "\t%s %q\n", name, imports[name])
+				continue
+			}
+			fmt.Fprintf(w, "\t%q\n", imports[name])
+		}
+	}
+	w.WriteString(")\n\n")
+}
@@ -0,0 +1,230 @@
+// Package mock provides MockPDClient, an IMPagerDuty that answers calls the test expects
+// with the values the test programmed, and reports unexpected calls and unmet expectations
+//
+// The methods of MockPDClient are generated from the IMPagerDuty interface, run `go generate ./...`
+// after changing the interface
+package mock
+
+//go:generate go run ./gen -interface ../mPagerDuty.go -output mpagerduty_mock.go
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	mPagerDuty "mpagerduty/pkg"
+)
+
+// TestingT is the part of *testing.T a Mock reports to
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Mock keeps the expectations of a mock and the calls made to it
+//
+// Like the calls a FakePDClient records, methods and their WithContext variants share a name,
+// and the context is not one of the arguments
+type Mock struct {
+	t TestingT
+
+	mu           sync.Mutex
+	expectations []*Expectation
+}
+
+// Expectation is a call a Mock expects, created with On
+type Expectation struct {
+	method string
+	args   []interface{}
+	values []interface{}
+
+	// times is the number of calls the expectation answers, 0 for any number of calls
+	times    int
+	optional bool
+	calls    int
+}
+
+func newMock(t TestingT) *Mock {
+	m := &Mock{t: t}
+	t.Cleanup(func() {
+		m.AssertExpectations(t)
+	})
+	return m
+}
+
+// On expects calls of method with args. Arguments are compared with reflect.DeepEqual,
+// unless an mPagerDuty.ArgumentMatcher such as mPagerDuty.Anything stands in for them.
+// Calls without args match calls with any arguments
+//
+// When several expectations match a call, the first one that has not answered all its calls answers it
+func (m *Mock) On(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expectation := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, expectation)
+	return expectation
+}
+
+// Return sets the values the calls return, in the order of the results of the method.
+// Untyped nil stands for the zero value of any result
+func (e *Expectation) Return(values ...interface{}) *Expectation {
+	e.values = values
+	return e
+}
+
+// Times makes the expectation answer exactly n calls
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once makes the expectation answer exactly one call
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// Maybe makes the expectation optional, so that it is not reported when it is never met
+func (e *Expectation) Maybe() *Expectation {
+	e.optional = true
+	return e
+}
+
+func (e *Expectation) String() string {
+	return fmt.Sprintf("%s(%s)", e.method, formatArgs(e.args))
+}
+
+// matches reports whether the call has the expected arguments
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method {
+		return false
+	}
+	if len(e.args) == 0 {
+		return true
+	}
+	if len(e.args) != len(args) {
+		return false
+	}
+	for i, arg := range args {
+		if matcher, ok := e.args[i].(mPagerDuty.ArgumentMatcher); ok {
+			if !matcher(arg) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(e.args[i], arg) {
+			return false
+		}
+	}
+	return true
+}
+
+// exhausted reports whether the expectation answered all the calls it expects
+func (e *Expectation) exhausted() bool {
+	return e.times > 0 && e.calls >= e.times
+}
+
+// unmet reports whether the expectation is still waiting for calls
+func (e *Expectation) unmet() bool {
+	if e.optional {
+		return false
+	}
+	if e.times > 0 {
+		return e.calls < e.times
+	}
+	return e.calls == 0
+}
+
+// AssertExpectations asserts that every expectation that is not optional got the calls it expects.
+// Mocks assert this by themselves when the test ends
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, expectation := range m.expectations {
+		if !expectation.unmet() {
+			continue
+		}
+		ok = false
+		if expectation.times > 0 {
+			t.Errorf("expected %s to be called %d times, but it was called %d times", expectation, expectation.times, expectation.calls)
+		} else {
+			t.Errorf("expected %s to be called, but it was not", expectation)
+		}
+	}
+	return ok
+}
+
+// called answers a call of method that returns results values, reporting calls nobody expects
+func (m *Mock) called(method string, results int, args ...interface{}) []interface{} {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values := make([]interface{}, results)
+	for _, expectation := range m.expectations {
+		if expectation.exhausted() || !expectation.matches(method, args) {
+			continue
+		}
+		expectation.calls++
+		if len(expectation.values) != results {
+			m.t.Errorf("%s returns %d values, but the expectation %s returns %d", method, results, expectation, len(expectation.values))
+			return values
+		}
+		copy(values, expectation.values)
+		return values
+	}
+
+	m.t.Errorf("unexpected call %s(%s)%s", method, formatArgs(args), m.describeExpectations(method))
+	return values
+}
+
+// describeExpectations lists the expectations of method for reports of unexpected calls
+func (m *Mock) describeExpectations(method string) string {
+	var list strings.Builder
+	for _, expectation := range m.expectations {
+		if expectation.method != method {
+			continue
+		}
+		list.WriteString("\n\texpected ")
+		list.WriteString(expectation.String())
+		if expectation.exhausted() {
+			fmt.Fprintf(&list, ", already called %d times", expectation.calls)
+		}
+	}
+	return list.String()
+}
+
+// result converts the i-th value a call returns to the type of the i-th result of method
+func result[T any](m *Mock, method string, values []interface{}, i int) T {
+	m.t.Helper()
+	var zero T
+	if values[i] == nil {
+		return zero
+	}
+	value, ok := values[i].(T)
+	if !ok {
+		m.t.Errorf("%s returns %s as result %d, but the expectation returns %T", method, reflect.TypeOf(&zero).Elem(), i, values[i])
+		return zero
+	}
+	return value
+}
+
+func formatArgs(args []interface{}) string {
+	parts := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case string:
+			parts = append(parts, fmt.Sprintf("%q", arg))
+		case mPagerDuty.ArgumentMatcher:
+			parts = append(parts, "<matcher>")
+		default:
+			parts = append(parts, fmt.Sprintf("%+v", arg))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
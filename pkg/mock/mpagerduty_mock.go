@@ -0,0 +1,336 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+	mPagerDuty "mpagerduty/pkg"
+)
+
+// MockPDClient is an IMPagerDuty that answers the calls its Mock expects
+type MockPDClient struct {
+	*Mock
+}
+
+// MockPDClient fails to compile when it does not implement every method of IMPagerDuty
+var _ mPagerDuty.IMPagerDuty = (*MockPDClient)(nil)
+
+// NewMockPDClient returns a MockPDClient that reports to t, and asserts its expectations when the test ends
+func NewMockPDClient(t TestingT) *MockPDClient {
+	return &MockPDClient{Mock: newMock(t)}
+}
+
+func (m *MockPDClient) GetOnCallsByScheduleIDs(scheduleIDs []string) ([]pagerduty.OnCall, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsByScheduleIDs", 2, scheduleIDs)
+	return result[[]pagerduty.OnCall](m.Mock, "GetOnCallsByScheduleIDs", values, 0), result[error](m.Mock, "GetOnCallsByScheduleIDs", values, 1)
+}
+
+func (m *MockPDClient) GetOnCallsByScheduleIDsWithContext(ctx context.Context, scheduleIDs []string) ([]pagerduty.OnCall, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsByScheduleIDs", 2, scheduleIDs)
+	return result[[]pagerduty.OnCall](m.Mock, "GetOnCallsByScheduleIDs", values, 0), result[error](m.Mock, "GetOnCallsByScheduleIDs", values, 1)
+}
+
+func (m *MockPDClient) GetOnCallsWithOptions(arg0 *pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsWithOptions", 2, arg0)
+	return result[*pagerduty.ListOnCallsResponse](m.Mock, "GetOnCallsWithOptions", values, 0), result[error](m.Mock, "GetOnCallsWithOptions", values, 1)
+}
+
+func (m *MockPDClient) GetOnCallsWithOptionsWithContext(ctx context.Context, options *pagerduty.ListOnCallOptions) (*pagerduty.ListOnCallsResponse, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsWithOptions", 2, options)
+	return result[*pagerduty.ListOnCallsResponse](m.Mock, "GetOnCallsWithOptions", values, 0), result[error](m.Mock, "GetOnCallsWithOptions", values, 1)
+}
+
+func (m *MockPDClient) GetOnCallsAt(scheduleIDs []string, escalationPolicyIDs []string, at time.Time) ([]mPagerDuty.OnCallLevel, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsAt", 2, scheduleIDs, escalationPolicyIDs, at)
+	return result[[]mPagerDuty.OnCallLevel](m.Mock, "GetOnCallsAt", values, 0), result[error](m.Mock, "GetOnCallsAt", values, 1)
+}
+
+func (m *MockPDClient) GetOnCallsAtWithContext(ctx context.Context, scheduleIDs []string, escalationPolicyIDs []string, at time.Time) ([]mPagerDuty.OnCallLevel, error) {
+	m.t.Helper()
+	values := m.called("GetOnCallsAt", 2, scheduleIDs, escalationPolicyIDs, at)
+	return result[[]mPagerDuty.OnCallLevel](m.Mock, "GetOnCallsAt", values, 0), result[error](m.Mock, "GetOnCallsAt", values, 1)
+}
+
+func (m *MockPDClient) ListAllUsers(options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
+	m.t.Helper()
+	values := m.called("ListAllUsers", 2, options)
+	return result[[]pagerduty.User](m.Mock, "ListAllUsers", values, 0), result[error](m.Mock, "ListAllUsers", values, 1)
+}
+
+func (m *MockPDClient) ListAllUsersWithContext(ctx context.Context, options pagerduty.ListUsersOptions) ([]pagerduty.User, error) {
+	m.t.Helper()
+	values := m.called("ListAllUsers", 2, options)
+	return result[[]pagerduty.User](m.Mock, "ListAllUsers", values, 0), result[error](m.Mock, "ListAllUsers", values, 1)
+}
+
+func (m *MockPDClient) GetUserByID(id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	m.t.Helper()
+	values := m.called("GetUserByID", 2, id, options)
+	return result[*pagerduty.User](m.Mock, "GetUserByID", values, 0), result[error](m.Mock, "GetUserByID", values, 1)
+}
+
+func (m *MockPDClient) GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	m.t.Helper()
+	values := m.called("GetUserByID", 2, id, options)
+	return result[*pagerduty.User](m.Mock, "GetUserByID", values, 0), result[error](m.Mock, "GetUserByID", values, 1)
+}
+
+func (m *MockPDClient) GetUserIDbyName(name string) (string, error) {
+	m.t.Helper()
+	values := m.called("GetUserIDbyName", 2, name)
+	return result[string](m.Mock, "GetUserIDbyName", values, 0), result[error](m.Mock, "GetUserIDbyName", values, 1)
+}
+
+func (m *MockPDClient) GetUserIDbyNameWithContext(ctx context.Context, name string) (string, error) {
+	m.t.Helper()
+	values := m.called("GetUserIDbyName", 2, name)
+	return result[string](m.Mock, "GetUserIDbyName", values, 0), result[error](m.Mock, "GetUserIDbyName", values, 1)
+}
+
+func (m *MockPDClient) GetUsersIDsByNames(names []string) ([]string, error) {
+	m.t.Helper()
+	values := m.called("GetUsersIDsByNames", 2, names)
+	return result[[]string](m.Mock, "GetUsersIDsByNames", values, 0), result[error](m.Mock, "GetUsersIDsByNames", values, 1)
+}
+
+func (m *MockPDClient) GetUsersIDsByNamesWithContext(ctx context.Context, names []string) ([]string, error) {
+	m.t.Helper()
+	values := m.called("GetUsersIDsByNames", 2, names)
+	return result[[]string](m.Mock, "GetUsersIDsByNames", values, 0), result[error](m.Mock, "GetUsersIDsByNames", values, 1)
+}
+
+func (m *MockPDClient) GetScheduleIDbyName(name string) (string, string, error) {
+	m.t.Helper()
+	values := m.called("GetScheduleIDbyName", 3, name)
+	return result[string](m.Mock, "GetScheduleIDbyName", values, 0), result[string](m.Mock, "GetScheduleIDbyName", values, 1), result[error](m.Mock, "GetScheduleIDbyName", values, 2)
+}
+
+func (m *MockPDClient) GetScheduleIDbyNameWithContext(ctx context.Context, name string) (string, string, error) {
+	m.t.Helper()
+	values := m.called("GetScheduleIDbyName", 3, name)
+	return result[string](m.Mock, "GetScheduleIDbyName", values, 0), result[string](m.Mock, "GetScheduleIDbyName", values, 1), result[error](m.Mock, "GetScheduleIDbyName", values, 2)
+}
+
+func (m *MockPDClient) ResolveSchedule(name string) (*mPagerDuty.ScheduleMatch, error) {
+	m.t.Helper()
+	values := m.called("ResolveSchedule", 2, name)
+	return result[*mPagerDuty.ScheduleMatch](m.Mock, "ResolveSchedule", values, 0), result[error](m.Mock, "ResolveSchedule", values, 1)
+}
+
+func (m *MockPDClient) ResolveScheduleWithContext(ctx context.Context, name string) (*mPagerDuty.ScheduleMatch, error) {
+	m.t.Helper()
+	values := m.called("ResolveSchedule", 2, name)
+	return result[*mPagerDuty.ScheduleMatch](m.Mock, "ResolveSchedule", values, 0), result[error](m.Mock, "ResolveSchedule", values, 1)
+}
+
+func (m *MockPDClient) GetScheduleByID(id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("GetScheduleByID", 2, id, options)
+	return result[*pagerduty.Schedule](m.Mock, "GetScheduleByID", values, 0), result[error](m.Mock, "GetScheduleByID", values, 1)
+}
+
+func (m *MockPDClient) GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("GetScheduleByID", 2, id, options)
+	return result[*pagerduty.Schedule](m.Mock, "GetScheduleByID", values, 0), result[error](m.Mock, "GetScheduleByID", values, 1)
+}
+
+func (m *MockPDClient) ListSchedules(options mPagerDuty.ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("ListSchedules", 2, options)
+	return result[[]pagerduty.Schedule](m.Mock, "ListSchedules", values, 0), result[error](m.Mock, "ListSchedules", values, 1)
+}
+
+func (m *MockPDClient) ListSchedulesWithContext(ctx context.Context, options mPagerDuty.ListSchedulesOptions) ([]pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("ListSchedules", 2, options)
+	return result[[]pagerduty.Schedule](m.Mock, "ListSchedules", values, 0), result[error](m.Mock, "ListSchedules", values, 1)
+}
+
+func (m *MockPDClient) CreateSchedule(schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("CreateSchedule", 2, schedule)
+	return result[*pagerduty.Schedule](m.Mock, "CreateSchedule", values, 0), result[error](m.Mock, "CreateSchedule", values, 1)
+}
+
+func (m *MockPDClient) CreateScheduleWithContext(ctx context.Context, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("CreateSchedule", 2, schedule)
+	return result[*pagerduty.Schedule](m.Mock, "CreateSchedule", values, 0), result[error](m.Mock, "CreateSchedule", values, 1)
+}
+
+func (m *MockPDClient) UpdateSchedule(id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("UpdateSchedule", 2, id, schedule)
+	return result[*pagerduty.Schedule](m.Mock, "UpdateSchedule", values, 0), result[error](m.Mock, "UpdateSchedule", values, 1)
+}
+
+func (m *MockPDClient) UpdateScheduleWithContext(ctx context.Context, id string, schedule pagerduty.Schedule) (*pagerduty.Schedule, error) {
+	m.t.Helper()
+	values := m.called("UpdateSchedule", 2, id, schedule)
+	return result[*pagerduty.Schedule](m.Mock, "UpdateSchedule", values, 0), result[error](m.Mock, "UpdateSchedule", values, 1)
+}
+
+func (m *MockPDClient) DeleteSchedule(id string) error {
+	m.t.Helper()
+	values := m.called("DeleteSchedule", 1, id)
+	return result[error](m.Mock, "DeleteSchedule", values, 0)
+}
+
+func (m *MockPDClient) DeleteScheduleWithContext(ctx context.Context, id string) error {
+	m.t.Helper()
+	values := m.called("DeleteSchedule", 1, id)
+	return result[error](m.Mock, "DeleteSchedule", values, 0)
+}
+
+func (m *MockPDClient) GetRenderedSchedule(scheduleID string, since time.Time, until time.Time, timeZone string) (*mPagerDuty.RenderedSchedule, error) {
+	m.t.Helper()
+	values := m.called("GetRenderedSchedule", 2, scheduleID, since, until, timeZone)
+	return result[*mPagerDuty.RenderedSchedule](m.Mock, "GetRenderedSchedule", values, 0), result[error](m.Mock, "GetRenderedSchedule", values, 1)
+}
+
+func (m *MockPDClient) GetRenderedScheduleWithContext(ctx context.Context, scheduleID string, since time.Time, until time.Time, timeZone string) (*mPagerDuty.RenderedSchedule, error) {
+	m.t.Helper()
+	values := m.called("GetRenderedSchedule", 2, scheduleID, since, until, timeZone)
+	return result[*mPagerDuty.RenderedSchedule](m.Mock, "GetRenderedSchedule", values, 0), result[error](m.Mock, "GetRenderedSchedule", values, 1)
+}
+
+func (m *MockPDClient) GetOverrides(scheduleID string, since string, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error) {
+	m.t.Helper()
+	values := m.called("GetOverrides", 2, scheduleID, since, until, includeOverflow)
+	return result[*pagerduty.ListOverridesResponse](m.Mock, "GetOverrides", values, 0), result[error](m.Mock, "GetOverrides", values, 1)
+}
+
+func (m *MockPDClient) GetOverridesWithContext(ctx context.Context, scheduleID string, since string, until string, includeOverflow bool) (*pagerduty.ListOverridesResponse, error) {
+	m.t.Helper()
+	values := m.called("GetOverrides", 2, scheduleID, since, until, includeOverflow)
+	return result[*pagerduty.ListOverridesResponse](m.Mock, "GetOverrides", values, 0), result[error](m.Mock, "GetOverrides", values, 1)
+}
+
+func (m *MockPDClient) CreateOverride(scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	m.t.Helper()
+	values := m.called("CreateOverride", 2, scheduleID, userID, start, end)
+	return result[*pagerduty.Override](m.Mock, "CreateOverride", values, 0), result[error](m.Mock, "CreateOverride", values, 1)
+}
+
+func (m *MockPDClient) CreateOverrideWithContext(ctx context.Context, scheduleID string, userID string, start string, end string) (*pagerduty.Override, error) {
+	m.t.Helper()
+	values := m.called("CreateOverride", 2, scheduleID, userID, start, end)
+	return result[*pagerduty.Override](m.Mock, "CreateOverride", values, 0), result[error](m.Mock, "CreateOverride", values, 1)
+}
+
+func (m *MockPDClient) RemoveOverride(scheduleID string, overrideID string) error {
+	m.t.Helper()
+	values := m.called("RemoveOverride", 1, scheduleID, overrideID)
+	return result[error](m.Mock, "RemoveOverride", values, 0)
+}
+
+func (m *MockPDClient) RemoveOverrideWithContext(ctx context.Context, scheduleID string, overrideID string) error {
+	m.t.Helper()
+	values := m.called("RemoveOverride", 1, scheduleID, overrideID)
+	return result[error](m.Mock, "RemoveOverride", values, 0)
+}
+
+func (m *MockPDClient) ListAllTags(options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
+	m.t.Helper()
+	values := m.called("ListAllTags", 2, options)
+	return result[[]*pagerduty.Tag](m.Mock, "ListAllTags", values, 0), result[error](m.Mock, "ListAllTags", values, 1)
+}
+
+func (m *MockPDClient) ListAllTagsWithContext(ctx context.Context, options pagerduty.ListTagOptions) ([]*pagerduty.Tag, error) {
+	m.t.Helper()
+	values := m.called("ListAllTags", 2, options)
+	return result[[]*pagerduty.Tag](m.Mock, "ListAllTags", values, 0), result[error](m.Mock, "ListAllTags", values, 1)
+}
+
+func (m *MockPDClient) GetIndicentsByEscalationPolicy(escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("GetIndicentsByEscalationPolicy", 2, escalationPolicyID, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "GetIndicentsByEscalationPolicy", values, 0), result[error](m.Mock, "GetIndicentsByEscalationPolicy", values, 1)
+}
+
+func (m *MockPDClient) GetIndicentsByEscalationPolicyWithContext(ctx context.Context, escalationPolicyID string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("GetIndicentsByEscalationPolicy", 2, escalationPolicyID, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "GetIndicentsByEscalationPolicy", values, 0), result[error](m.Mock, "GetIndicentsByEscalationPolicy", values, 1)
+}
+
+func (m *MockPDClient) GetIndicentsByTag(tagName string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("GetIndicentsByTag", 2, tagName, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "GetIndicentsByTag", values, 0), result[error](m.Mock, "GetIndicentsByTag", values, 1)
+}
+
+func (m *MockPDClient) GetIndicentsByTagWithContext(ctx context.Context, tagName string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("GetIndicentsByTag", 2, tagName, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "GetIndicentsByTag", values, 0), result[error](m.Mock, "GetIndicentsByTag", values, 1)
+}
+
+func (m *MockPDClient) CreateIncident(title string, serviceID string, urgency string, details string, escalationPolicyID string) (*pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("CreateIncident", 2, title, serviceID, urgency, details, escalationPolicyID)
+	return result[*pagerduty.Incident](m.Mock, "CreateIncident", values, 0), result[error](m.Mock, "CreateIncident", values, 1)
+}
+
+func (m *MockPDClient) CreateIncidentWithContext(ctx context.Context, title string, serviceID string, urgency string, details string, escalationPolicyID string) (*pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("CreateIncident", 2, title, serviceID, urgency, details, escalationPolicyID)
+	return result[*pagerduty.Incident](m.Mock, "CreateIncident", values, 0), result[error](m.Mock, "CreateIncident", values, 1)
+}
+
+func (m *MockPDClient) SearchIncidents(serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("SearchIncidents", 2, serviceQuery, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "SearchIncidents", values, 0), result[error](m.Mock, "SearchIncidents", values, 1)
+}
+
+func (m *MockPDClient) SearchIncidentsWithContext(ctx context.Context, serviceQuery string, timeRange time.Duration) ([]pagerduty.Incident, error) {
+	m.t.Helper()
+	values := m.called("SearchIncidents", 2, serviceQuery, timeRange)
+	return result[[]pagerduty.Incident](m.Mock, "SearchIncidents", values, 0), result[error](m.Mock, "SearchIncidents", values, 1)
+}
+
+func (m *MockPDClient) SearchIncidentLogs(pdIncidentId string, logType string) (*string, error) {
+	m.t.Helper()
+	values := m.called("SearchIncidentLogs", 2, pdIncidentId, logType)
+	return result[*string](m.Mock, "SearchIncidentLogs", values, 0), result[error](m.Mock, "SearchIncidentLogs", values, 1)
+}
+
+func (m *MockPDClient) SearchIncidentLogsWithContext(ctx context.Context, pdIncidentId string, logType string) (*string, error) {
+	m.t.Helper()
+	values := m.called("SearchIncidentLogs", 2, pdIncidentId, logType)
+	return result[*string](m.Mock, "SearchIncidentLogs", values, 0), result[error](m.Mock, "SearchIncidentLogs", values, 1)
+}
+
+func (m *MockPDClient) GetEscalationPoliciesByTag(tagID string) (*pagerduty.ListEPResponse, error) {
+	m.t.Helper()
+	values := m.called("GetEscalationPoliciesByTag", 2, tagID)
+	return result[*pagerduty.ListEPResponse](m.Mock, "GetEscalationPoliciesByTag", values, 0), result[error](m.Mock, "GetEscalationPoliciesByTag", values, 1)
+}
+
+func (m *MockPDClient) GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (*pagerduty.ListEPResponse, error) {
+	m.t.Helper()
+	values := m.called("GetEscalationPoliciesByTag", 2, tagID)
+	return result[*pagerduty.ListEPResponse](m.Mock, "GetEscalationPoliciesByTag", values, 0), result[error](m.Mock, "GetEscalationPoliciesByTag", values, 1)
+}
+
+func (m *MockPDClient) UpdateEscalationPolicy(id string, userID string, serviceID string, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	m.t.Helper()
+	values := m.called("UpdateEscalationPolicy", 2, id, userID, serviceID, teamID, escalation)
+	return result[*pagerduty.EscalationPolicy](m.Mock, "UpdateEscalationPolicy", values, 0), result[error](m.Mock, "UpdateEscalationPolicy", values, 1)
+}
+
+func (m *MockPDClient) UpdateEscalationPolicyWithContext(ctx context.Context, id string, userID string, serviceID string, teamID string, escalation []pagerduty.APIObject) (*pagerduty.EscalationPolicy, error) {
+	m.t.Helper()
+	values := m.called("UpdateEscalationPolicy", 2, id, userID, serviceID, teamID, escalation)
+	return result[*pagerduty.EscalationPolicy](m.Mock, "UpdateEscalationPolicy", values, 0), result[error](m.Mock, "UpdateEscalationPolicy", values, 1)
+}
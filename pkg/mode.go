@@ -0,0 +1,99 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Mode selects what a client created by GetMPagerDutyClient talks to
+type Mode int
+
+const (
+	// ModeLive sends requests to the actual PagerDuty API
+	ModeLive Mode = iota
+	// ModeFake returns a FakePDClient that never leaves the process. It honors the fixtures, faults, clock,
+	// configuration, hooks and tracer of the client options, has no use for the options about sending requests,
+	// and fails with ErrInvalidArgument along with WithCassette
+	ModeFake
+	// ModeReplay runs the actual client code paths against recorded API interactions
+	ModeReplay
+	// ModeEmulator runs the actual client code paths against a local PagerDuty REST API emulator
+	ModeEmulator
+)
+
+var modeNames = map[Mode]string{
+	ModeLive:     "live",
+	ModeFake:     "fake",
+	ModeReplay:   "replay",
+	ModeEmulator: "emulator",
+}
+
+func (m Mode) String() string {
+	if name, ok := modeNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode returns the mode called name, as printed by Mode.String
+func ParseMode(name string) (Mode, error) {
+	for mode, modeName := range modeNames {
+		if strings.EqualFold(strings.TrimSpace(name), modeName) {
+			return mode, nil
+		}
+	}
+	return ModeLive, fmt.Errorf("%w: unknown mode '%s'", ErrInvalidArgument, name)
+}
+
+// WithMode makes GetMPagerDutyClient create a client of the given mode, regardless of the environment
+func WithMode(mode Mode) ClientOption {
+	return func(o *clientOptions) {
+		o.mode = &mode
+	}
+}
+
+// WithModeFromEnv lets the environment pick the mode when WithMode is not passed. PD_MODE may name
+// any mode, otherwise the client is faked when RUNNING_IN_JENKINS or LOCAL_DEV_TESTING are set to true
+func WithModeFromEnv() ClientOption {
+	return func(o *clientOptions) {
+		o.modeFromEnv = true
+	}
+}
+
+// ModeOf reports which mode pd is running in. The second return value is false
+// for implementations that do not come from this package
+func ModeOf(pd IMPagerDuty) (Mode, bool) {
+	if moded, ok := pd.(interface{ Mode() Mode }); ok {
+		return moded.Mode(), true
+	}
+	return ModeLive, false
+}
+
+// Mode reports which mode the client is running in
+func (c *client) Mode() Mode {
+	return c.mode
+}
+
+// Mode always reports ModeFake
+func (fakeClient *FakePDClient) Mode() Mode {
+	return ModeFake
+}
+
+// resolveMode picks the mode the options ask for, falling back to ModeLive
+func (o *clientOptions) resolveMode() (Mode, error) {
+	if o.mode != nil {
+		return *o.mode, nil
+	}
+	if !o.modeFromEnv {
+		return ModeLive, nil
+	}
+
+	if name := os.Getenv("PD_MODE"); name != "" {
+		return ParseMode(name)
+	}
+	if os.Getenv("RUNNING_IN_JENKINS") == "true" || os.Getenv("LOCAL_DEV_TESTING") == "true" {
+		return ModeFake, nil
+	}
+	return ModeLive, nil
+}
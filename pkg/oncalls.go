@@ -0,0 +1,129 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// OnCallLevel is who is on call at one level of an escalation policy
+type OnCallLevel struct {
+	EscalationPolicyID   string
+	EscalationPolicyName string
+	// Level is the escalation level, counted from 1
+	Level uint
+	Users []OnCallUser
+}
+
+// OnCallUser is a user on call at an escalation level
+type OnCallUser struct {
+	UserID   string
+	UserName string
+	// ScheduleID and ScheduleName are empty when the escalation rule targets the user directly
+	ScheduleID   string
+	ScheduleName string
+	// Start and End bound the on-call shift, and are zero when the user is on call permanently
+	Start time.Time
+	End   time.Time
+}
+
+// validateOnCallsAt checks the arguments of GetOnCallsAt
+func validateOnCallsAt(scheduleIDs, escalationPolicyIDs []string, at time.Time) error {
+	if len(scheduleIDs) == 0 && len(escalationPolicyIDs) == 0 {
+		return fmt.Errorf("%w: passed parameters 'scheduleIDs' or 'escalationPolicyIDs' must be specified", ErrInvalidArgument)
+	}
+	for _, id := range append(append([]string(nil), scheduleIDs...), escalationPolicyIDs...) {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("%w: could not get on-calls because the passed IDs had empty string(s)", ErrInvalidArgument)
+		}
+	}
+	if at.IsZero() {
+		return fmt.Errorf("%w: passed parameter 'at' must be specified", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// onCallsAtOptions asks PagerDuty for the on-calls of the schedules and escalation policies that overlap at,
+// rendered in timeZone
+func onCallsAtOptions(scheduleIDs, escalationPolicyIDs []string, at time.Time, timeZone string) pagerduty.ListOnCallOptions {
+	return pagerduty.ListOnCallOptions{
+		TimeZone:            timeZone,
+		ScheduleIDs:         scheduleIDs,
+		EscalationPolicyIDs: escalationPolicyIDs,
+		Since:               at.UTC().Format(time.RFC3339),
+		Until:               at.Add(time.Second).UTC().Format(time.RFC3339),
+		Total:               true,
+	}
+}
+
+// newOnCallLevels groups the on-calls under way at at by escalation policy and level,
+// sorted by escalation policy ID and level
+func newOnCallLevels(onCalls []pagerduty.OnCall, at time.Time) ([]OnCallLevel, error) {
+	type levelKey struct {
+		policyID string
+		level    uint
+	}
+	levels := map[levelKey]*OnCallLevel{}
+	for _, onCall := range onCalls {
+		user := OnCallUser{
+			UserID:       onCall.User.ID,
+			UserName:     onCall.User.Summary,
+			ScheduleID:   onCall.Schedule.ID,
+			ScheduleName: onCall.Schedule.Summary,
+		}
+		var err error
+		if onCall.Start != "" {
+			if user.Start, err = time.Parse(time.RFC3339, onCall.Start); err != nil {
+				return nil, fmt.Errorf("failed to parse the start of an on-call: %w", err)
+			}
+		}
+		if onCall.End != "" {
+			if user.End, err = time.Parse(time.RFC3339, onCall.End); err != nil {
+				return nil, fmt.Errorf("failed to parse the end of an on-call: %w", err)
+			}
+		}
+		// PagerDuty returns the on-calls that overlap the second starting at at, some of which may start within it
+		if (!user.Start.IsZero() && user.Start.After(at)) || (!user.End.IsZero() && !user.End.After(at)) {
+			continue
+		}
+
+		key := levelKey{policyID: onCall.EscalationPolicy.ID, level: onCall.EscalationLevel}
+		level, ok := levels[key]
+		if !ok {
+			level = &OnCallLevel{
+				EscalationPolicyID:   onCall.EscalationPolicy.ID,
+				EscalationPolicyName: onCall.EscalationPolicy.Summary,
+				Level:                onCall.EscalationLevel,
+			}
+			levels[key] = level
+		}
+		if !containsOnCallUser(level.Users, user) {
+			level.Users = append(level.Users, user)
+		}
+	}
+
+	result := make([]OnCallLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, *level)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EscalationPolicyID != result[j].EscalationPolicyID {
+			return result[i].EscalationPolicyID < result[j].EscalationPolicyID
+		}
+		return result[i].Level < result[j].Level
+	})
+	return result, nil
+}
+
+// containsOnCallUser reports whether users already holds the user on call through the same schedule
+func containsOnCallUser(users []OnCallUser, user OnCallUser) bool {
+	for _, existing := range users {
+		if existing.UserID == user.UserID && existing.ScheduleID == user.ScheduleID {
+			return true
+		}
+	}
+	return false
+}
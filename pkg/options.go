@@ -0,0 +1,171 @@
+package mPagerDuty
+
+import (
+	"net/http"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+const (
+	// DefaultAPIEndpoint is the base URL of PagerDuty's REST API in the US service region
+	DefaultAPIEndpoint = "https://api.pagerduty.com"
+	// EUAPIEndpoint is the base URL of PagerDuty's REST API in the EU service region
+	EUAPIEndpoint = "https://api.eu.pagerduty.com"
+)
+
+// ClientOption customizes the client created by GetMPagerDutyClient
+type ClientOption func(*clientOptions)
+
+type clientOptions struct {
+	apiEndpoint string
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	userAgent   string
+	from        string
+	maxItems    uint
+	retry       RetryPolicy
+	config      *Config
+	mode        *Mode
+	modeFromEnv bool
+	hooks       []Hook
+	tracer      Tracer
+	fixtures    *Fixtures
+	faults      *Faults
+	cassette    string
+	clock       Clock
+	// cassetteTransport wraps the transport to record or replay the cassette
+	cassetteTransport func(http.RoundTripper) http.RoundTripper
+}
+
+func newClientOptions(options []ClientOption) *clientOptions {
+	o := &clientOptions{
+		apiEndpoint: DefaultAPIEndpoint,
+		retry:       DefaultRetryPolicy,
+		clock:       SystemClock,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// WithAPIEndpoint points the client at a different PagerDuty REST API base URL,
+// e.g. a regional endpoint, a proxy or a local test server
+func WithAPIEndpoint(endpoint string) ClientOption {
+	return func(o *clientOptions) {
+		o.apiEndpoint = endpoint
+	}
+}
+
+// WithEURegion points the client at PagerDuty's EU service region
+func WithEURegion() ClientOption {
+	return WithAPIEndpoint(EUAPIEndpoint)
+}
+
+// WithHTTPClient makes the client send its requests through httpClient
+// instead of go-pagerduty's default HTTP client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport makes the client send its requests through transport.
+// When combined with WithHTTPClient, transport replaces that client's own transport
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithFrom sets the email address of the PagerDuty user that requests such as
+// CreateIncident are made on behalf of, taking precedence over Config.RequesterEmail
+func WithFrom(email string) ClientOption {
+	return func(o *clientOptions) {
+		o.from = email
+	}
+}
+
+// WithMaxItems caps the number of items the list methods that return every item, such as ListAllUsers,
+// collect across all pages, 0 means no cap. A list holding more items fails with ErrTruncated rather than
+// returning a part of it. Lookups by name and filtered lists such as SearchIncidents look at every item regardless
+func WithMaxItems(maxItems uint) ClientOption {
+	return func(o *clientOptions) {
+		o.maxItems = maxItems
+	}
+}
+
+// loadConfig returns the configuration passed with WithConfig, or the one in the environment
+func (o *clientOptions) loadConfig() (Config, error) {
+	var config Config
+	if o.config != nil {
+		config = *o.config
+	} else {
+		envConfig, err := LoadConfigFromEnv()
+		if err != nil {
+			return Config{}, err
+		}
+		config = envConfig
+	}
+
+	if o.from != "" {
+		config.RequesterEmail = o.from
+	}
+	return config, config.Validate()
+}
+
+// pagerDutyOptions translates the options into go-pagerduty client options
+func (o *clientOptions) pagerDutyOptions() []pagerduty.ClientOptions {
+	return []pagerduty.ClientOptions{pagerduty.WithAPIEndpoint(o.apiEndpoint)}
+}
+
+// buildHTTPClient returns the HTTP client the go-pagerduty client should use,
+// or nil when nothing was customized and go-pagerduty's default client is fine
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	if o.httpClient == nil && o.transport == nil && o.userAgent == "" && o.cassetteTransport == nil {
+		return nil
+	}
+
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		*httpClient = *o.httpClient
+	}
+
+	transport := httpClient.Transport
+	if o.transport != nil {
+		transport = o.transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if o.cassetteTransport != nil {
+		transport = o.cassetteTransport(transport)
+	}
+
+	if o.userAgent != "" {
+		transport = &userAgentTransport{base: transport, userAgent: o.userAgent}
+	}
+
+	httpClient.Transport = transport
+	return httpClient
+}
+
+// userAgentTransport overrides the User-Agent header, which go-pagerduty
+// always sets to its own value right before sending a request
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
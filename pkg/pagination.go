@@ -0,0 +1,77 @@
+package mPagerDuty
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// pageFunc fetches a single page of results starting at offset, returning the items
+// together with the pagination state reported by the API
+type pageFunc[T any] func(ctx context.Context, offset, limit uint) ([]T, pagerduty.APIListObject, error)
+
+// paginator drives PagerDuty's classic offset/limit pagination
+// API documentation: https://developer.pagerduty.com/docs/ZG9jOjExMDI5NTU4-pagination
+type paginator struct {
+	// limit is the page size requested from the API
+	limit uint
+	// maxItems caps the number of items collectPages collects, 0 means no cap. Lists that hold
+	// more items fail with ErrTruncated instead of returning a part of them.
+	// walkPages is never capped, so lookups and filtered walks see every item
+	maxItems uint
+}
+
+func newPaginator(pageSize uint) paginator {
+	return paginator{limit: pageSize}
+}
+
+// walkPages calls visit for every item of every page returned by fetch until visit asks to stop,
+// the API reports that there are no more pages, or ctx is done
+func walkPages[T any](ctx context.Context, p paginator, fetch pageFunc[T], visit func(item T) (bool, error)) error {
+	var offset uint = 0
+	for number := 1; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		items, page, err := fetch(withPage(ctx, number), offset, p.limit)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			next, err := visit(item)
+			if err != nil {
+				return err
+			}
+			if !next {
+				return nil
+			}
+		}
+
+		// An empty page guards against endpoints that keep reporting More
+		// without ever returning anything new
+		if !page.More || len(items) < 1 {
+			return nil
+		}
+		offset = offset + uint(len(items))
+	}
+}
+
+// collectPages returns every item of every page returned by fetch, or ErrTruncated
+// once there are more than the maxItems cap allows
+func collectPages[T any](ctx context.Context, p paginator, fetch pageFunc[T]) ([]T, error) {
+	var all []T
+	err := walkPages(ctx, p, fetch, func(item T) (bool, error) {
+		if p.maxItems > 0 && uint(len(all)) >= p.maxItems {
+			return false, fmt.Errorf("%w: the list holds more than the %d items the client may collect", ErrTruncated, p.maxItems)
+		}
+		all = append(all, item)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,150 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// RenderedSchedule is who a schedule puts on call between Since and Until. Every list of entries
+// is sorted by start, cut to the window, and merges contiguous entries of the same user
+type RenderedSchedule struct {
+	ScheduleID string `json:"schedule_id"`
+	// TimeZone is the time zone the times of the entries are in
+	TimeZone string    `json:"time_zone"`
+	Since    time.Time `json:"since"`
+	Until    time.Time `json:"until"`
+
+	// Final lists who is on call once layers and overrides are combined
+	Final []RenderedEntry `json:"final"`
+	// Overrides lists who the overrides of the schedule put on call
+	Overrides []RenderedEntry `json:"overrides"`
+	// Layers lists who every layer puts on call, in the order of the layers of the schedule
+	Layers []RenderedLayer `json:"layers"`
+}
+
+// RenderedLayer is who one layer of a schedule puts on call
+type RenderedLayer struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Entries []RenderedEntry `json:"entries"`
+}
+
+// RenderedEntry is a stretch of time a user is on call
+type RenderedEntry struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	UserID   string    `json:"user_id"`
+	UserName string    `json:"user_name"`
+}
+
+// validateRenderWindow checks the arguments of GetRenderedSchedule, returning the location of timeZone,
+// which is nil when timeZone is empty and the schedule's own time zone applies
+func validateRenderWindow(scheduleID string, since, until time.Time, timeZone string) (*time.Location, error) {
+	if strings.TrimSpace(scheduleID) == "" {
+		return nil, fmt.Errorf("%w: passed parameter 'scheduleID' must be specified", ErrInvalidArgument)
+	}
+	if since.IsZero() || until.IsZero() {
+		return nil, fmt.Errorf("%w: passed parameters 'since' and 'until' must be specified", ErrInvalidArgument)
+	}
+	if !until.After(since) {
+		return nil, fmt.Errorf("%w: passed parameter 'until' must be after 'since'", ErrInvalidArgument)
+	}
+	if timeZone == "" {
+		return nil, nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone '%s'", ErrInvalidArgument, timeZone)
+	}
+	return location, nil
+}
+
+// renderOptions asks PagerDuty to render a schedule between since and until in timeZone
+func renderOptions(since, until time.Time, timeZone string) pagerduty.GetScheduleOptions {
+	return pagerduty.GetScheduleOptions{
+		TimeZone: timeZone,
+		Since:    since.UTC().Format(time.RFC3339),
+		Until:    until.UTC().Format(time.RFC3339),
+	}
+}
+
+// newRenderedSchedule converts the rendered entries of schedule to a RenderedSchedule in location,
+// or in the time zone of the schedule when location is nil
+func newRenderedSchedule(schedule *pagerduty.Schedule, since, until time.Time, location *time.Location) (*RenderedSchedule, error) {
+	if location == nil {
+		var err error
+		if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+			return nil, fmt.Errorf("schedule has an unknown time zone '%s': %w", schedule.TimeZone, err)
+		}
+	}
+
+	rendered := &RenderedSchedule{
+		ScheduleID: schedule.ID,
+		TimeZone:   location.String(),
+		Since:      since.In(location),
+		Until:      until.In(location),
+	}
+	var err error
+	if rendered.Final, err = renderedEntries(schedule.FinalSchedule.RenderedScheduleEntries, since, until, location); err != nil {
+		return nil, err
+	}
+	if rendered.Overrides, err = renderedEntries(schedule.OverrideSubschedule.RenderedScheduleEntries, since, until, location); err != nil {
+		return nil, err
+	}
+	for _, layer := range schedule.ScheduleLayers {
+		entries, err := renderedEntries(layer.RenderedScheduleEntries, since, until, location)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Layers = append(rendered.Layers, RenderedLayer{ID: layer.ID, Name: layer.Name, Entries: entries})
+	}
+	return rendered, nil
+}
+
+// renderedEntries parses entries, cuts them to the window between since and until,
+// and merges contiguous entries of the same user
+func renderedEntries(entries []pagerduty.RenderedScheduleEntry, since, until time.Time, location *time.Location) ([]RenderedEntry, error) {
+	parsed := make([]RenderedEntry, 0, len(entries))
+	for _, entry := range entries {
+		start, err := time.Parse(time.RFC3339, entry.Start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the start of a rendered schedule entry: %w", err)
+		}
+		end, err := time.Parse(time.RFC3339, entry.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the end of a rendered schedule entry: %w", err)
+		}
+		if start.Before(since) {
+			start = since
+		}
+		if end.After(until) {
+			end = until
+		}
+		if !end.After(start) {
+			continue
+		}
+		parsed = append(parsed, RenderedEntry{
+			Start:    start.In(location),
+			End:      end.In(location),
+			UserID:   entry.User.ID,
+			UserName: entry.User.Summary,
+		})
+	}
+	sort.SliceStable(parsed, func(i, j int) bool { return parsed[i].Start.Before(parsed[j].Start) })
+
+	merged := make([]RenderedEntry, 0, len(parsed))
+	for _, entry := range parsed {
+		if last := len(merged) - 1; last >= 0 && merged[last].UserID == entry.UserID && !entry.Start.After(merged[last].End) {
+			if entry.End.After(merged[last].End) {
+				merged[last].End = entry.End
+			}
+			continue
+		}
+		merged = append(merged, entry)
+	}
+	return merged, nil
+}
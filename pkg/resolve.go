@@ -0,0 +1,149 @@
+package mPagerDuty
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// ScheduleMatch is the schedule ResolveSchedule matched a name to
+type ScheduleMatch struct {
+	ID       string
+	Name     string
+	TimeZone string
+	// Exact is false when the name only matched fuzzily, as part of the schedule name or with typos
+	Exact bool
+}
+
+// AmbiguousNameError is returned when a name matches several schedules equally well.
+// errors.Is matches it against ErrAmbiguous
+type AmbiguousNameError struct {
+	// Name is the name that was resolved
+	Name string
+	// Candidates lists every schedule the name matches, in the order PagerDuty lists them
+	Candidates []ScheduleMatch
+}
+
+func (e *AmbiguousNameError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, candidate := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("'%s' (%s)", candidate.Name, candidate.ID))
+	}
+	return fmt.Sprintf("schedule name '%s' is ambiguous, it matches %s", e.Name, strings.Join(candidates, ", "))
+}
+
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguous
+}
+
+// resolveScheduleName returns the schedule that name matches best. Names are compared once normalized,
+// with or without prefix in front of them. An exact match wins over a schedule whose name contains name,
+// which wins over a schedule whose name is a few typos away from name
+func resolveScheduleName(schedules []pagerduty.Schedule, name, prefix string) (*ScheduleMatch, error) {
+	prefix, err := normalizeName(prefix)
+	if err != nil {
+		return nil, err
+	}
+	query, err := normalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	query = trimNamePrefix(query, prefix)
+	maxTypos := len([]rune(query)) / 4
+
+	best := -1
+	var candidates []ScheduleMatch
+	for _, schedule := range schedules {
+		scheduleName, err := normalizeName(schedule.Name)
+		if err != nil {
+			return nil, err
+		}
+		scheduleName = trimNamePrefix(scheduleName, prefix)
+
+		// ranks from 0 for an exact match, 1 for a partial one, and 1 more for every typo
+		var rank int
+		switch {
+		case scheduleName == query:
+			rank = 0
+		case strings.Contains(scheduleName, query):
+			rank = 1
+		default:
+			typos := editDistance(scheduleName, query)
+			if typos > maxTypos {
+				continue
+			}
+			rank = 1 + typos
+		}
+
+		if best != -1 && rank > best {
+			continue
+		}
+		if rank != best {
+			best = rank
+			candidates = candidates[:0]
+		}
+		candidates = append(candidates, ScheduleMatch{ID: schedule.ID, Name: schedule.Name, TimeZone: schedule.TimeZone, Exact: rank == 0})
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("%w: no schedule matches the name '%s'", ErrNotFound, name)
+	case 1:
+		return &candidates[0], nil
+	default:
+		return nil, &AmbiguousNameError{Name: name, Candidates: candidates}
+	}
+}
+
+// normalizeName returns name without diacritics, in lower case and with its words separated by single spaces
+func normalizeName(name string) (string, error) {
+	normalized, err := normalizeString(name)
+	if err != nil {
+		return "", fmt.Errorf("error while normalizing name '%s': %w", name, err)
+	}
+	return strings.Join(strings.Fields(strings.ToLower(normalized)), " "), nil
+}
+
+// trimNamePrefix returns name without prefix and the space that follows it
+func trimNamePrefix(name, prefix string) string {
+	if prefix == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, prefix+" ")
+}
+
+// editDistance returns the number of runes to insert, delete or substitute, or of adjacent runes
+// to swap, to turn a into b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// the rows of the distances between the prefixes of a and b, for the last three prefixes of a
+	beforePrevious := make([]int, len(rb)+1)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j-1]+cost, minInt(previous[j]+1, current[j-1]+1))
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				current[j] = minInt(current[j], beforePrevious[j-2]+1)
+			}
+		}
+		beforePrevious, previous, current = previous, current, beforePrevious
+	}
+	return previous[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,166 @@
+package mPagerDuty
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// RetryPolicy controls how the client retries requests that were rate limited (HTTP 429)
+// or failed with a transient server error (HTTP 5xx)
+//
+// Delays grow exponentially from BaseDelay up to MaxDelay with full jitter, unless PagerDuty
+// says when the rate limit resets, in which case that time is waited instead
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the first retry
+	BaseDelay time.Duration
+	// MaxDelay is the upper bound of any single delay, 0 means no bound
+	MaxDelay time.Duration
+	// Budget is the upper bound of the total time spent waiting between attempts of one request, 0 means no bound
+	Budget time.Duration
+}
+
+// DefaultRetryPolicy is used by clients that were not configured with WithRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Budget:      time.Minute,
+}
+
+// NoRetry disables retries altogether
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for the client
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retry = policy
+	}
+}
+
+// Requests that create something are not idempotent: a failed attempt may have been processed anyway,
+// so they are only retried when PagerDuty rejected them outright because of the rate limit
+const (
+	idempotent    = true
+	nonIdempotent = false
+)
+
+var (
+	jitterMu sync.Mutex
+	jitter   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// withRetry runs call until it succeeds, fails with an error that must not be retried,
+// or the policy's attempts or budget are used up. API errors are returned as *APIError
+func withRetry[T any](ctx context.Context, c *client, idempotent bool, call func(ctx context.Context) (T, error)) (T, error) {
+	var waited time.Duration
+	for attempt := 1; ; attempt++ {
+		hint := &rateLimitHint{}
+		result, err := call(context.WithValue(withAttempt(ctx, attempt), rateLimitHintKey{}, hint))
+		if err == nil || attempt >= c.retry.MaxAttempts || !shouldRetry(err, idempotent) {
+			return result, wrapAPIError(err)
+		}
+
+		delay := c.retry.delay(attempt, hint.retryAfter)
+		if c.retry.Budget > 0 && waited+delay > c.retry.Budget {
+			return result, wrapAPIError(err)
+		}
+		waited += delay
+
+		info := callInfoFrom(ctx)
+		c.observer.emit(ctx, Event{Kind: EventRetry, Method: info.method, Args: info.args, Page: info.page, Attempt: attempt, Latency: delay, Err: err})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(err error, idempotent bool) bool {
+	var apiErr pagerduty.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.RateLimited() {
+		return true
+	}
+	return idempotent && apiErr.Temporary()
+}
+
+// delay returns how long to wait before the next attempt
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	backoff := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || backoff < p.MaxDelay) && backoff <= math.MaxInt64/2; i++ {
+		backoff *= 2
+	}
+	if p.MaxDelay > 0 && backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitter.Int63n(int64(backoff) + 1))
+}
+
+type rateLimitHintKey struct{}
+
+// rateLimitHint carries the rate limit reset time of a response from the HTTP layer, where
+// go-pagerduty still exposes the headers, back up to withRetry
+type rateLimitHint struct {
+	retryAfter time.Duration
+}
+
+// rateLimitRecorder reads rate limit headers of throttled responses into the request's rateLimitHint
+type rateLimitRecorder struct {
+	base pagerduty.HTTPClient
+}
+
+func (r *rateLimitRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.Do(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+
+	if hint, ok := req.Context().Value(rateLimitHintKey{}).(*rateLimitHint); ok {
+		hint.retryAfter = parseRetryAfter(resp.Header, time.Now())
+	}
+	return resp, err
+}
+
+// parseRetryAfter understands the standard Retry-After header in both of its forms
+// as well as the ratelimit-reset header PagerDuty sends with throttled responses
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	for _, name := range []string{"Retry-After", "Ratelimit-Reset"} {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil && at.After(now) {
+			return at.Sub(now)
+		}
+	}
+	return 0
+}
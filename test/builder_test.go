@@ -0,0 +1,88 @@
+package mPagerDuty_test
+
+import (
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilders(t *testing.T) {
+	createdAt := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	incident := builder.Incident("QINCIDENT1").Title("Disk full on db-1").Status("resolved").
+		Urgency("low").Service("PSERVICE").EscalationPolicy("PPOLICY1").CreatedAt(createdAt).Build()
+	assert.Equal(t, "Disk full on db-1", incident.Summary)
+	assert.Equal(t, "Disk full on db-1", incident.Description)
+	assert.Equal(t, "resolved", incident.Status)
+	assert.Equal(t, "low", incident.Urgency)
+	assert.Equal(t, pagerduty.APIObject{ID: "PSERVICE", Type: "service_reference"}, incident.Service)
+	assert.Equal(t, "PPOLICY1", incident.EscalationPolicy.ID)
+	assert.Equal(t, "2030-01-01T10:00:00Z", incident.CreatedAt)
+
+	// defaults
+	triggered := builder.Incident("QINCIDENT2").Build()
+	assert.Equal(t, "triggered", triggered.Status)
+	assert.Equal(t, "high", triggered.Urgency)
+	assert.NotEmpty(t, triggered.CreatedAt)
+
+	user := builder.User("PUSER01").Name("Ada Lovelace").Team("PTEAM01", "Platform").Build()
+	assert.Equal(t, "Ada Lovelace", user.Name)
+	assert.Equal(t, "puser01@example.com", user.Email)
+	assert.Equal(t, "UTC", user.Timezone)
+	assert.Equal(t, "PTEAM01", user.Teams[0].ID)
+
+	london, err := time.LoadLocation("Europe/London")
+	assert.Nil(t, err)
+	start := time.Date(2030, 7, 1, 9, 0, 0, 0, london)
+	override := builder.Override("QOVERRIDE1").User("PUSER01").Between(start, start.Add(8*time.Hour)).Build()
+	assert.Equal(t, "2030-07-01T09:00:00+01:00", override.Start)
+	assert.Equal(t, "2030-07-01T17:00:00+01:00", override.End)
+	assert.Equal(t, builder.UserReference("PUSER01"), override.User)
+
+	policy := builder.EscalationPolicy("PPOLICY1").Name("Platform Escalation").
+		Rule(30, builder.ScheduleReference("PSCHED1")).
+		Rule(15, builder.UserReference("PUSER01")).
+		Build()
+	assert.Len(t, policy.EscalationRules, 2)
+	assert.Equal(t, uint(15), policy.EscalationRules[1].Delay)
+
+	layer := builder.ScheduleLayer("Weekly").Users("PUSER01", "PUSER02").Rotation(start, 24*time.Hour).Build()
+	assert.Equal(t, "2030-07-01T09:00:00+01:00", layer.RotationVirtualStart)
+	assert.Equal(t, uint(86400), layer.RotationTurnLengthSeconds)
+	assert.Equal(t, "PUSER02", layer.Users[1].User.ID)
+	schedule := builder.Schedule("PSCHED1").Name("Platform Primary").TimeZone("Europe/London").Team("PTEAM01").Layer(layer).Build()
+	assert.Equal(t, "Platform Primary", schedule.Summary)
+	assert.Equal(t, "Europe/London", schedule.TimeZone)
+	assert.Equal(t, "PTEAM01", schedule.Teams[0].ID)
+	assert.Equal(t, "Weekly", schedule.ScheduleLayers[0].Name)
+
+	onCall := builder.OnCall(user).EscalationPolicy(policy).Level(2).Build()
+	assert.Equal(t, "PUSER01", onCall.User.ID)
+	assert.Equal(t, uint(2), onCall.EscalationLevel)
+}
+
+func TestBuiltFixtures(t *testing.T) {
+	user := builder.User("PUSER01").Name("Ada Lovelace").Build()
+	policy := builder.EscalationPolicy("PPOLICY1").Rule(30, builder.UserReference(user.ID)).Build()
+	fixtures := &mPagerDuty.Fixtures{
+		Users:              []pagerduty.User{user},
+		EscalationPolicies: []pagerduty.EscalationPolicy{policy},
+		OnCalls:            []pagerduty.OnCall{builder.OnCall(user).EscalationPolicy(policy).Build()},
+		Incidents: []pagerduty.Incident{
+			builder.Incident("QINCIDENT1").EscalationPolicy(policy.ID).Build(),
+		},
+	}
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	incidents, err := mPD.GetIndicentsByEscalationPolicy(policy.ID, -60)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 1)
+	onCalls, err := mPD.GetOnCallsWithOptions(&pagerduty.ListOnCallOptions{EscalationPolicyIDs: []string{policy.ID}})
+	assert.Nil(t, err)
+	assert.Len(t, onCalls.OnCalls, 1)
+}
@@ -0,0 +1,195 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingPD counts the lookups that reach the decorated fake client
+type countingPD struct {
+	mPagerDuty.IMPagerDuty
+	calls map[string]int
+}
+
+func newCountingPD() *countingPD {
+	return &countingPD{IMPagerDuty: &mPagerDuty.FakePDClient{}, calls: map[string]int{}}
+}
+
+func (c *countingPD) GetUserByIDWithContext(ctx context.Context, id string, options pagerduty.GetUserOptions) (*pagerduty.User, error) {
+	c.calls["GetUserByID"]++
+	return c.IMPagerDuty.GetUserByIDWithContext(ctx, id, options)
+}
+
+func (c *countingPD) GetScheduleIDbyNameWithContext(ctx context.Context, name string) (string, string, error) {
+	c.calls["GetScheduleIDbyName"]++
+	return c.IMPagerDuty.GetScheduleIDbyNameWithContext(ctx, name)
+}
+
+func (c *countingPD) GetEscalationPoliciesByTagWithContext(ctx context.Context, tagID string) (*pagerduty.ListEPResponse, error) {
+	c.calls["GetEscalationPoliciesByTag"]++
+	return c.IMPagerDuty.GetEscalationPoliciesByTagWithContext(ctx, tagID)
+}
+
+func TestCachingClient(t *testing.T) {
+	inner := newCountingPD()
+	mPD := mPagerDuty.NewCachingClient(inner, mPagerDuty.DefaultCacheTTLs)
+
+	for i := 0; i < 3; i++ {
+		user, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "Timur Kalandarov", user.Name)
+
+		id, _, err := mPD.GetScheduleIDbyName("Caleb Young TESTING")
+		assert.Nil(t, err)
+		assert.Equal(t, "PUHMCXV", id)
+	}
+	assert.Equal(t, 1, inner.calls["GetUserByID"])
+	assert.Equal(t, 1, inner.calls["GetScheduleIDbyName"])
+
+	// errors are not cached
+	_, err := mPD.GetUserByID("  ", pagerduty.GetUserOptions{})
+	assert.NotNil(t, err)
+	_, err = mPD.GetUserByID("  ", pagerduty.GetUserOptions{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, inner.calls["GetUserByID"])
+
+	// mutating calls invalidate what they may have changed
+	_, err = mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2022-09-01T14:00:00-06:00", "2022-09-02T00:00:00-06:00")
+	assert.Nil(t, err)
+	_, _, err = mPD.GetScheduleIDbyName("Caleb Young TESTING")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls["GetScheduleIDbyName"])
+
+	_, err = mPD.GetEscalationPoliciesByTag("P74RRGF")
+	assert.Nil(t, err)
+	_, err = mPD.UpdateEscalationPolicy("PP2PMMD", "PJ6XOVE", "", "", []pagerduty.APIObject{})
+	assert.Nil(t, err)
+	_, err = mPD.GetEscalationPoliciesByTag("P74RRGF")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls["GetEscalationPoliciesByTag"])
+
+	// explicit invalidation
+	mPD.Invalidate(mPagerDuty.CacheUsers)
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, inner.calls["GetUserByID"])
+
+	mPD.InvalidateAll()
+	_, _, err = mPD.GetScheduleIDbyName("Caleb Young TESTING")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, inner.calls["GetScheduleIDbyName"])
+}
+
+func TestCachingClientCopies(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	fake, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	mPD := mPagerDuty.NewCachingClient(fake, mPagerDuty.DefaultCacheTTLs)
+
+	// changing what one caller got never changes what the next caller gets, for the first result or a cached one
+	for i := 0; i < 2; i++ {
+		user, err := mPD.GetUserByID("PUSER01", pagerduty.GetUserOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "Ada Lovelace", user.Name)
+		user.Name = "MUTATED"
+
+		schedule, err := mPD.GetScheduleByID("PSCHED1", pagerduty.GetScheduleOptions{})
+		assert.Nil(t, err)
+		if assert.NotEmpty(t, schedule.ScheduleLayers) {
+			assert.NotEqual(t, "MUTATED", schedule.ScheduleLayers[0].Name)
+			schedule.ScheduleLayers[0].Name = "MUTATED"
+		}
+
+		match, err := mPD.ResolveSchedule("Platform Primary")
+		assert.Nil(t, err)
+		assert.Equal(t, "PSCHED1", match.ID)
+		match.ID = "MUTATED"
+
+		policies, err := mPD.GetEscalationPoliciesByTag("PTAG001")
+		assert.Nil(t, err)
+		if assert.NotEmpty(t, policies.EscalationPolicies) {
+			assert.NotEqual(t, "MUTATED", policies.EscalationPolicies[0].Summary)
+			policies.EscalationPolicies[0].Summary = "MUTATED"
+		}
+
+		tags, err := mPD.ListAllTags(pagerduty.ListTagOptions{})
+		assert.Nil(t, err)
+		if assert.NotEmpty(t, tags) {
+			assert.NotEqual(t, "MUTATED", tags[0].Label)
+			tags[0].Label = "MUTATED"
+		}
+	}
+}
+
+// interleavingPD runs during in the middle of the next schedule lookup, like a change made concurrently
+type interleavingPD struct {
+	*countingPD
+	during func()
+}
+
+func (c *interleavingPD) GetScheduleByIDWithContext(ctx context.Context, id string, options pagerduty.GetScheduleOptions) (*pagerduty.Schedule, error) {
+	c.calls["GetScheduleByID"]++
+	schedule, err := c.IMPagerDuty.GetScheduleByIDWithContext(ctx, id, options)
+	if during := c.during; during != nil {
+		c.during = nil
+		during()
+	}
+	return schedule, err
+}
+
+func TestCachingClientInvalidationDuringLoad(t *testing.T) {
+	inner := &interleavingPD{countingPD: newCountingPD()}
+	mPD := mPagerDuty.NewCachingClient(inner, mPagerDuty.DefaultCacheTTLs)
+
+	// the override is created after the lookup read the schedule, but before the lookup stored it
+	inner.during = func() {
+		_, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+		assert.Nil(t, err)
+	}
+	_, err := mPD.GetScheduleByID("PUHMCXV", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+
+	// so what the lookup read is not cached
+	_, err = mPD.GetScheduleByID("PUHMCXV", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls["GetScheduleByID"])
+	_, err = mPD.GetScheduleByID("PUHMCXV", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls["GetScheduleByID"])
+}
+
+func TestCachingClientTTL(t *testing.T) {
+	inner := newCountingPD()
+	mPD := mPagerDuty.NewCachingClient(inner, mPagerDuty.CacheTTLs{Users: 20 * time.Millisecond})
+	clock := mPagerDuty.NewTestClock(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	mPD.SetClock(clock)
+
+	_, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inner.calls["GetUserByID"])
+
+	clock.Advance(30 * time.Millisecond)
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inner.calls["GetUserByID"])
+
+	// resources without a TTL are not cached
+	for i := 0; i < 2; i++ {
+		_, _, err = mPD.GetScheduleIDbyName("Caleb Young TESTING")
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, inner.calls["GetScheduleIDbyName"])
+
+	mode, ok := mPagerDuty.ModeOf(mPagerDuty.NewCachingClient(&mPagerDuty.FakePDClient{}, mPagerDuty.DefaultCacheTTLs))
+	assert.True(t, ok)
+	assert.Equal(t, mPagerDuty.ModeFake, mode)
+}
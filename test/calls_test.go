@@ -0,0 +1,78 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingT collects the failures assertions report
+type recordingT struct {
+	failures []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestCallRecording(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+
+	override, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+	_, err = mPD.GetUserByID("PNOSUCH", pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+	// calls the fake makes itself are not recorded
+	_, err = mPD.GetIndicentsByTag("GSOC", -30)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NotNil(t, mPD.RemoveOverrideWithContext(ctx, "PUHMCXV", override.ID))
+
+	calls := mPD.Calls()
+	assert.Len(t, calls, 4)
+	assert.Equal(t, "CreateOverride", calls[0].Method)
+	assert.Equal(t, []interface{}{"PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z"}, calls[0].Args)
+	assert.Equal(t, []interface{}{override}, calls[0].Results)
+	assert.Nil(t, calls[0].Err)
+	assert.True(t, errors.Is(calls[1].Err, mPagerDuty.ErrNotFound))
+	assert.True(t, errors.Is(calls[3].Err, context.Canceled))
+	assert.Len(t, mPD.CallsTo("GetIndicentsByTag"), 1)
+
+	mPD.ResetCalls()
+	assert.Empty(t, mPD.Calls())
+}
+
+func TestCallAssertions(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+	_, err := mPD.GetOverrides("PUHMCXV", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", false)
+	assert.Nil(t, err)
+	_, err = mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+
+	// passing
+	assert.True(t, mPD.AssertCalled(t, "CreateOverride"))
+	assert.True(t, mPD.AssertCalled(t, "CreateOverride", "PUHMCXV", "PJ6XOVE", mPagerDuty.Anything, "2030-01-03T00:00:00Z"))
+	assert.True(t, mPD.AssertNotCalled(t, "UpdateEscalationPolicy"))
+	assert.True(t, mPD.AssertNotCalled(t, "CreateOverride", "PUHMCXV", "PNOSUCH", mPagerDuty.Anything, mPagerDuty.Anything))
+	assert.True(t, mPD.AssertNumberOfCalls(t, "GetOverrides", 1))
+	assert.True(t, mPD.AssertCallOrder(t, "GetOverrides", "CreateOverride"))
+
+	// failing
+	failing := &recordingT{}
+	assert.False(t, mPD.AssertCalled(failing, "CreateOverride", "PUHMCXV", "PNOSUCH", mPagerDuty.Anything, mPagerDuty.Anything))
+	assert.False(t, mPD.AssertCalled(failing, "RemoveOverride"))
+	assert.False(t, mPD.AssertNotCalled(failing, "GetOverrides"))
+	assert.False(t, mPD.AssertNumberOfCalls(failing, "CreateOverride", 2))
+	assert.False(t, mPD.AssertCallOrder(failing, "CreateOverride", "GetOverrides"))
+	assert.Len(t, failing.failures, 5)
+	assert.Contains(t, failing.failures[0], `CreateOverride("PUHMCXV", "PJ6XOVE"`)
+}
@@ -0,0 +1,140 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	emulator, err := mPagerDuty.NewEmulator(nil)
+	assert.Nil(t, err)
+
+	// record
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+
+	recordedUser, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	override, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Nil(t, mPD.RemoveOverride("PUHMCXV", override.ID))
+	_, err = mPD.GetUserByID("PNOSUCH", pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+	emulator.Close()
+
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), authtoken)
+	assert.Contains(t, string(data), "REDACTED")
+
+	// replay, with nothing listening at the recorded endpoint anymore
+	mPD, err = mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeReplay),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+
+	user, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, recordedUser, user)
+	replayed, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, override, replayed)
+	assert.Nil(t, mPD.RemoveOverride("PUHMCXV", override.ID))
+	_, err = mPD.GetUserByID("PNOSUCH", pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// every interaction answers once, and requests nobody recorded fail
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.ErrorContains(t, err, "no recorded interaction left for GET /users/PJ6XOVE")
+	_, err = mPD.ListAllTags(pagerduty.ListTagOptions{})
+	assert.ErrorContains(t, err, "no recorded interaction left for GET /tags")
+}
+
+func TestCassetteIncidentWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+
+	// record, with the since window computed from a clock shortly after the incident was created
+	clock := mPagerDuty.NewTestClock(incidentCreatedAt.Add(time.Hour))
+	emulator.SetClock(clock)
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithClock(clock),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+	recorded, err := mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -120)
+	assert.Nil(t, err)
+	assert.Len(t, recorded, 1)
+	emulator.Close()
+
+	// replay at another time, which moves the since window the client sends
+	mPD, err = mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeReplay),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+	replayed, err := mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -120)
+	assert.Nil(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	// the interaction still answers once
+	_, err = mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -120)
+	assert.ErrorContains(t, err, "no recorded interaction left for GET /incidents")
+}
+
+func TestCassetteCallerWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+	_, err = mPD.GetOverrides("PSCHED1", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", false)
+	assert.Nil(t, err)
+	emulator.Close()
+
+	// windows the caller asks for are part of the request, so another window is not answered with the recorded one
+	mPD, err = mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeReplay),
+		mPagerDuty.WithCassette(path))
+	assert.Nil(t, err)
+	_, err = mPD.GetOverrides("PSCHED1", "2030-02-01T00:00:00Z", "2030-02-02T00:00:00Z", false)
+	assert.ErrorContains(t, err, "no recorded interaction left for GET /schedules/PSCHED1/overrides")
+	_, err = mPD.GetOverrides("PSCHED1", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", false)
+	assert.Nil(t, err)
+}
+
+func TestReplayRequiresCassette(t *testing.T) {
+	_, err := mPagerDuty.GetMPagerDutyClient(authtoken, mPagerDuty.WithMode(mPagerDuty.ModeReplay))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+	_, err = mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeReplay),
+		mPagerDuty.WithCassette(filepath.Join(t.TempDir(), "missing.json")))
+	assert.NotNil(t, err)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	assert.Nil(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	_, err = mPagerDuty.GetMPagerDutyClient(authtoken, mPagerDuty.WithMode(mPagerDuty.ModeReplay), mPagerDuty.WithCassette(path))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+}
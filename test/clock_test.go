@@ -0,0 +1,109 @@
+package mPagerDuty_test
+
+import (
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// the only incident of testdata/incidents.json was created at 2030-01-01T10:00:00Z
+var incidentCreatedAt = time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func TestTestClock(t *testing.T) {
+	clock := mPagerDuty.NewTestClock(incidentCreatedAt)
+	assert.Equal(t, incidentCreatedAt, clock.Now())
+	clock.Advance(time.Hour)
+	assert.Equal(t, incidentCreatedAt.Add(time.Hour), clock.Now())
+	clock.Set(incidentCreatedAt)
+	assert.Equal(t, incidentCreatedAt, clock.Now())
+}
+
+func TestFakeClock(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+	clock := mPagerDuty.NewTestClock(incidentCreatedAt.Add(2 * time.Hour))
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeFake),
+		mPagerDuty.WithFixtures(fixtures),
+		mPagerDuty.WithClock(clock))
+	assert.Nil(t, err)
+
+	incidents, err := mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -60)
+	assert.Nil(t, err)
+	assert.Empty(t, incidents)
+	incidents, err = mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -180)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 1)
+
+	// new incidents are created at the time the clock reads, and age as it advances
+	incident, err := mPD.CreateIncident("Disk full on db-2", "PSERVICE", "", "", "PPOLICY1")
+	assert.Nil(t, err)
+	assert.Equal(t, "2030-01-01T12:00:00Z", incident.CreatedAt)
+	incidents, err = mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -60)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 1)
+	clock.Advance(2 * time.Hour)
+	incidents, err = mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -60)
+	assert.Nil(t, err)
+	assert.Empty(t, incidents)
+}
+
+func TestLiveClock(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+	clock := mPagerDuty.NewTestClock(incidentCreatedAt.Add(2 * time.Hour))
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+	defer emulator.Close()
+	emulator.SetClock(clock)
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithClock(clock))
+	assert.Nil(t, err)
+
+	// the since window the client sends is computed from the clock
+	incidents, err := mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -60)
+	assert.Nil(t, err)
+	assert.Empty(t, incidents)
+	incidents, err = mPD.GetIndicentsByTag("platform", -180)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 1)
+
+	incident, err := mPD.CreateIncident("Disk full on db-2", "PSERVICE", "", "", "PPOLICY1")
+	assert.Nil(t, err)
+	assert.Equal(t, "2030-01-01T12:00:00Z", incident.CreatedAt)
+}
+
+func TestNilClock(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+
+	// a nil clock stands for the system clock, like it does for WithClock
+	fake, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	fake.SetClock(nil)
+	_, err = fake.SearchIncidents("Platform", time.Hour)
+	assert.Nil(t, err)
+
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+	defer emulator.Close()
+	emulator.SetClock(nil)
+	emulated, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL))
+	assert.Nil(t, err)
+	incident, err := emulated.CreateIncident("Disk full on db-2", "PSERVICE", "", "", "PPOLICY1")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, incident.CreatedAt)
+
+	cache := mPagerDuty.NewCachingClient(fake, mPagerDuty.DefaultCacheTTLs)
+	cache.SetClock(nil)
+	_, err = cache.GetUserByID("PUSER01", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,124 @@
+package mPagerDuty_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("PD_SCHEDULEPREFIX", "GSOC")
+	t.Setenv("PD_TEAMID", "P83EOFI, PXYZ123")
+	t.Setenv("PD_PAGESIZE", "")
+	t.Setenv("PD_TIMEZONE", "America/Denver")
+	t.Setenv("PD_REQUESTER_EMAIL", "")
+
+	config, err := mPagerDuty.LoadConfigFromEnv()
+	assert.Nil(t, err)
+	assert.Equal(t, "GSOC", config.SchedulePrefix)
+	assert.Equal(t, []string{"P83EOFI", "PXYZ123"}, config.TeamIDs)
+	assert.Equal(t, uint(100), config.PageSize)
+	assert.Equal(t, "America/Denver", config.DefaultTimeZone)
+	assert.Equal(t, mPagerDuty.DefaultConfig().RequesterEmail, config.RequesterEmail)
+
+	t.Setenv("PD_PAGESIZE", "500")
+	_, err = mPagerDuty.LoadConfigFromEnv()
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+}
+
+func TestLoadConfigFromYAML(t *testing.T) {
+	tests := []struct {
+		content     string
+		expectedErr bool
+	}{
+		{
+			"schedule_prefix: VIDOPS\nteam_ids: [P83EOFI]\npage_size: 25\nrequester_email: oncall@example.com\n",
+			false,
+		},
+		{
+			"schedule_prefix: [unterminated\n",
+			true,
+		},
+		{
+			"default_time_zone: Mars/Olympus_Mons\n",
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), "mpagerduty.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte(test.content), 0o600))
+
+		config, err := mPagerDuty.LoadConfigFromYAML(path)
+		if test.expectedErr {
+			assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, "VIDOPS", config.SchedulePrefix)
+			assert.Equal(t, []string{"P83EOFI"}, config.TeamIDs)
+			assert.Equal(t, uint(25), config.PageSize)
+			assert.Equal(t, "UTC", config.DefaultTimeZone)
+			assert.Equal(t, "oncall@example.com", config.RequesterEmail)
+		}
+	}
+
+	_, err := mPagerDuty.LoadConfigFromYAML(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NotNil(t, err)
+}
+
+func TestConfigPerClient(t *testing.T) {
+	var teamIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		teamIDs = r.URL.Query()["team_ids[]"]
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/users" {
+			_ = json.NewEncoder(w).Encode(pagerduty.ListUsersResponse{Users: []pagerduty.User{
+				{APIObject: pagerduty.APIObject{ID: "PJ6XOVE"}, Name: "Timur Kalandarov"},
+			}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pagerduty.ListSchedulesResponse{Schedules: []pagerduty.Schedule{
+			{APIObject: pagerduty.APIObject{ID: "PGSOC01"}, Name: "GSOC Primary", TimeZone: "America/Denver"},
+			{APIObject: pagerduty.APIObject{ID: "PVID001"}, Name: "VIDOPS Primary", TimeZone: "America/Los_Angeles"},
+		}})
+	}))
+	defer server.Close()
+
+	gsocConfig := mPagerDuty.DefaultConfig()
+	gsocConfig.SchedulePrefix = "GSOC"
+	gsocConfig.TeamIDs = []string{"P83EOFI"}
+	vidopsConfig := mPagerDuty.DefaultConfig()
+	vidopsConfig.SchedulePrefix = "VIDOPS"
+
+	gsoc := liveClientForServer(t, server, mPagerDuty.WithConfig(gsocConfig))
+	vidops := liveClientForServer(t, server, mPagerDuty.WithConfig(vidopsConfig))
+
+	id, tz, err := gsoc.GetScheduleIDbyName("primary")
+	assert.Nil(t, err)
+	assert.Equal(t, "PGSOC01", id)
+	assert.Equal(t, "America/Denver", tz)
+
+	id, tz, err = vidops.GetScheduleIDbyName("primary")
+	assert.Nil(t, err)
+	assert.Equal(t, "PVID001", id)
+	assert.Equal(t, "America/Los_Angeles", tz)
+
+	userID, err := gsoc.GetUserIDbyName("Timur Kalandarov")
+	assert.Nil(t, err)
+	assert.Equal(t, "PJ6XOVE", userID)
+	assert.Equal(t, []string{"P83EOFI"}, teamIDs)
+
+	invalidConfig := mPagerDuty.DefaultConfig()
+	invalidConfig.PageSize = 0
+	_, err = mPagerDuty.GetMPagerDutyClient(authtoken, mPagerDuty.WithConfig(invalidConfig))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+}
@@ -0,0 +1,62 @@
+package mPagerDuty_test
+
+import (
+	"os"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/conformance"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fixtureTarget names the resources of testdata/fixtures.yaml and testdata/incidents.json
+var fixtureTarget = conformance.Target{
+	UserID:             "PUSER01",
+	UserName:           "Ada Lovelace",
+	OtherUserID:        "PUSER02",
+	OtherUserName:      "Grace Hopper",
+	ScheduleID:         "PSCHED1",
+	ScheduleName:       "Platform Primary",
+	ScheduleTimeZone:   "Europe/London",
+	EscalationPolicyID: "PPOLICY1",
+	TagID:              "PTAG001",
+	TagName:            "platform",
+	ServiceID:          "PSERVICE",
+	Changes:            true,
+}
+
+func TestFakeConformance(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) mPagerDuty.IMPagerDuty {
+		fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+		assert.Nil(t, err)
+		mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+		assert.Nil(t, err)
+		return mPD
+	}, fixtureTarget)
+}
+
+func TestEmulatorConformance(t *testing.T) {
+	conformance.Run(t, newEmulatedClient, fixtureTarget)
+}
+
+func TestLiveConformance(t *testing.T) {
+	if os.Getenv("RUNNING_IN_JENKINS") == "true" {
+		t.Skip("Skipping mPagerDuty TestLiveConformance in CI")
+	}
+
+	conformance.Run(t, func(t *testing.T) mPagerDuty.IMPagerDuty {
+		mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken)
+		assert.Nil(t, err)
+		return mPD
+	}, conformance.Target{
+		UserID:             "PWKNFGT", // Timur Kalandarov
+		UserName:           "Timur Kalandarov",
+		OtherUserID:        "P273W1N", // Caleb Young
+		OtherUserName:      "Caleb Young",
+		ScheduleID:         "P10QVCS",
+		EscalationPolicyID: "P23N6LT", // INCY DEV Escalation Policy
+		TagID:              "P74RRGF", // GSOC tag
+		TagName:            "GSOC",
+	})
+}
@@ -0,0 +1,96 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// coverageFixtures holds a schedule that only covers working hours, alternating daily between Ada
+// and a user who left, and a schedule Ada covers around the clock
+func coverageFixtures() *mPagerDuty.Fixtures {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &mPagerDuty.Fixtures{
+		Users: []pagerduty.User{builder.User("PUSER01").Name("Ada Lovelace").Build()},
+		Schedules: []pagerduty.Schedule{
+			builder.Schedule("PWORKING").Name("Working Hours").
+				Layer(builder.ScheduleLayer("Daily").Users("PUSER01", "PGONE01").Rotation(start, 24*time.Hour).Between(start, time.Time{}).
+					DailyRestriction("08:00:00", 10*time.Hour).Build()).
+				Build(),
+			builder.Schedule("PALWAYS").Name("Around the Clock").
+				Layer(builder.ScheduleLayer("Weekly").Users("PUSER01").Rotation(start, 7*24*time.Hour).Between(start, time.Time{}).Build()).
+				Build(),
+		},
+		Overrides: map[string][]pagerduty.Override{
+			"PWORKING": {builder.Override("QEVENING").User("PUSER01").Between(start.Add(20*time.Hour), start.Add(22*time.Hour)).Build()},
+		},
+	}
+}
+
+func TestAnalyzeCoverage(t *testing.T) {
+	fake, err := mPagerDuty.NewFakePDClient(coverageFixtures())
+	assert.Nil(t, err)
+	emulator, err := mPagerDuty.NewEmulator(coverageFixtures())
+	assert.Nil(t, err)
+	defer emulator.Close()
+	emulated, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL))
+	assert.Nil(t, err)
+
+	at := func(day, hour int) time.Time { return time.Date(2030, 1, day, hour, 0, 0, 0, time.UTC) }
+	interval := func(start, end time.Time) mPagerDuty.CoverageInterval {
+		return mPagerDuty.CoverageInterval{Start: start, End: end}
+	}
+
+	for name, mPD := range map[string]mPagerDuty.IMPagerDuty{"fake": fake, "emulator": emulated} {
+		reports, err := mPagerDuty.AnalyzeCoverage(context.Background(), mPD, []string{"PWORKING", "PALWAYS"}, at(1, 0), at(3, 0))
+		if !assert.Nil(t, err, name) || !assert.Len(t, reports, 2, name) {
+			continue
+		}
+
+		working := reports[0]
+		assert.Equal(t, "PWORKING", working.ScheduleID, name)
+		assert.Equal(t, "UTC", working.TimeZone, name)
+		assert.False(t, working.Covered(), name)
+		assert.Equal(t, []mPagerDuty.CoverageInterval{
+			interval(at(1, 0), at(1, 8)),
+			interval(at(1, 18), at(1, 20)),
+			interval(at(1, 22), at(2, 8)),
+			interval(at(2, 18), at(3, 0)),
+		}, working.Gaps, name)
+		assert.Equal(t, []mPagerDuty.CoverageInterval{interval(at(1, 20), at(1, 22))}, working.OverrideOnly, name)
+		assert.Equal(t, []mPagerDuty.RenderedEntry{{Start: at(2, 8), End: at(2, 18), UserID: "PGONE01"}}, working.DeactivatedUsers, name)
+
+		always := reports[1]
+		assert.Equal(t, "PALWAYS", always.ScheduleID, name)
+		assert.True(t, always.Covered(), name)
+		assert.Empty(t, always.Gaps, name)
+	}
+
+	// reports are ready to be sent along with an alert
+	reports, err := mPagerDuty.AnalyzeCoverage(context.Background(), fake, []string{"PALWAYS"}, at(1, 0), at(2, 0))
+	assert.Nil(t, err)
+	payload, err := json.Marshal(reports[0])
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"schedule_id": "PALWAYS", "time_zone": "UTC", "since": "2030-01-01T00:00:00Z", "until": "2030-01-02T00:00:00Z",
+		"gaps": [], "override_only": [], "deactivated_users": []
+	}`, string(payload))
+
+	_, err = mPagerDuty.AnalyzeCoverage(context.Background(), fake, nil, at(1, 0), at(2, 0))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%v", err)
+	_, err = mPagerDuty.AnalyzeCoverage(context.Background(), fake, []string{"PALWAYS"}, at(2, 0), at(1, 0))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%v", err)
+	_, err = mPagerDuty.AnalyzeCoverage(context.Background(), fake, []string{"PALWAYS", "PNOSUCH"}, at(1, 0), at(2, 0))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "%v", err)
+	assert.Contains(t, err.Error(), "PNOSUCH")
+}
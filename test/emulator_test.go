@@ -0,0 +1,181 @@
+package mPagerDuty_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEmulatedClient(t *testing.T) mPagerDuty.IMPagerDuty {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+	t.Cleanup(emulator.Close)
+
+	// a page size of one makes every list span several pages
+	config := mPagerDuty.DefaultConfig()
+	config.PageSize = 1
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithConfig(config),
+		mPagerDuty.WithRetryPolicy(mPagerDuty.NoRetry))
+	assert.Nil(t, err)
+	return mPD
+}
+
+func TestEmulatorLookups(t *testing.T) {
+	mPD := newEmulatedClient(t)
+
+	users, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+
+	userID, err := mPD.GetUserIDbyName("Grace Hopper")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER02", userID)
+
+	ids, err := mPD.GetUsersIDsByNames([]string{"Ada Lovelace"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PUSER01"}, ids)
+
+	user, err := mPD.GetUserByID("PUSER01", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "ada@example.com", user.Email)
+	_, err = mPD.GetUserByID("PNOSUCH", pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	id, tz, err := mPD.GetScheduleIDbyName("platform primary")
+	assert.Nil(t, err)
+	assert.Equal(t, "PSCHED1", id)
+	assert.Equal(t, "Europe/London", tz)
+
+	onCalls, err := mPD.GetOnCallsByScheduleIDs([]string{"PSCHED1"})
+	assert.Nil(t, err)
+	assert.Len(t, onCalls, 1)
+	assert.Equal(t, "PUSER01", onCalls[0].User.ID)
+
+	tags, err := mPD.ListAllTags(pagerduty.ListTagOptions{Query: "plat"})
+	assert.Nil(t, err)
+	assert.Len(t, tags, 1)
+
+	policies, err := mPD.GetEscalationPoliciesByTag("PTAG001")
+	assert.Nil(t, err)
+	assert.Len(t, policies.EscalationPolicies, 1)
+	assert.Equal(t, "PPOLICY1", policies.EscalationPolicies[0].ID)
+}
+
+func TestEmulatorChanges(t *testing.T) {
+	mPD := newEmulatedClient(t)
+	since := "2030-01-01T00:00:00Z"
+	until := "2030-01-02T00:00:00Z"
+
+	// overrides
+	override, err := mPD.CreateOverride("PSCHED1", "PUSER01", "2030-01-01T18:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER01", override.User.ID)
+
+	overrides, err := mPD.GetOverrides("PSCHED1", since, until, false)
+	assert.Nil(t, err)
+	assert.Len(t, overrides.Overrides, 2)
+	assert.Equal(t, until, overrides.Overrides[1].End)
+
+	assert.Nil(t, mPD.RemoveOverride("PSCHED1", override.ID))
+	err = mPD.RemoveOverride("PSCHED1", override.ID)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	_, err = mPD.CreateOverride("PSCHED1", "PUSER01", "tomorrow", "2030-01-03T00:00:00Z")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+	// incidents
+	incident, err := mPD.CreateIncident("Disk full on db-2", "PSERVICE", "low", "", "PPOLICY1")
+	assert.Nil(t, err)
+	assert.Equal(t, "triggered", incident.Status)
+	assert.Equal(t, uint(8), incident.IncidentNumber)
+
+	incidents, err := mPD.GetIndicentsByEscalationPolicy("PPOLICY1", -30)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 2)
+
+	incidents, err = mPD.GetIndicentsByTag("platform", -30)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 2)
+
+	summary, err := mPD.SearchIncidentLogs("QINCIDENT1", "resolve_log_entry")
+	assert.Nil(t, err)
+	assert.Equal(t, "Resolved by Ada Lovelace.", *summary)
+	summary, err = mPD.SearchIncidentLogs(incident.ID, "trigger_log_entry")
+	assert.Nil(t, err)
+	assert.Equal(t, "Triggered through the API.", *summary)
+
+	// escalation policies
+	policy, err := mPD.UpdateEscalationPolicy("PPOLICY1", "PUSER01", "PSERVICE", "", []pagerduty.APIObject{{ID: "PUSER02", Type: "user_reference"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "Platform Escalation", policy.Name)
+	assert.Len(t, policy.EscalationRules, 2)
+	_, err = mPD.UpdateEscalationPolicy("PNOSUCH", "PUSER01", "", "", []pagerduty.APIObject{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+}
+
+func TestEmulatorHTTP(t *testing.T) {
+	emulator, err := mPagerDuty.NewEmulator(nil)
+	assert.Nil(t, err)
+	defer emulator.Close()
+
+	get := func(path string) *http.Response {
+		request, err := http.NewRequest(http.MethodGet, emulator.URL+path, nil)
+		assert.Nil(t, err)
+		request.Header.Set("Authorization", "Token token="+authtoken)
+		response, err := http.DefaultClient.Do(request)
+		assert.Nil(t, err)
+		return response
+	}
+
+	response := get("/incidents?limit=1&offset=1&total=true")
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var incidents pagerduty.ListIncidentsResponse
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&incidents))
+	assert.Len(t, incidents.Incidents, 1)
+	assert.Equal(t, uint(1), incidents.Offset)
+	assert.Equal(t, uint(1), incidents.Limit)
+	assert.True(t, incidents.More)
+	assert.NotZero(t, incidents.Total)
+
+	response = get("/users?limit=lots")
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = get("/services")
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+	response, err = http.Get(emulator.URL + "/users")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestEmulatorClose(t *testing.T) {
+	emulator, err := mPagerDuty.NewEmulator(nil)
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithRetryPolicy(mPagerDuty.NoRetry))
+	assert.Nil(t, err)
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+
+	// once closed, nothing answers at its URL anymore
+	emulator.Close()
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,75 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidArgumentErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	clients := []mPagerDuty.IMPagerDuty{&mPagerDuty.FakePDClient{}, liveClientForServer(t, server)}
+	for _, mPD := range clients {
+		_, err := mPD.GetOnCallsByScheduleIDs(nil)
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+		_, err = mPD.GetUserByID("  ", pagerduty.GetUserOptions{})
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+		_, err = mPD.CreateOverride("P10QVCS", "", "2022-09-01T14:00:00-06:00", "2022-09-02T00:00:00-06:00")
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+		err = mPD.RemoveOverride("", "Q3WU06FHSCYOHG")
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+		assert.False(t, errors.Is(err, mPagerDuty.ErrNotFound))
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected error
+	}{
+		{http.StatusBadRequest, mPagerDuty.ErrInvalidArgument},
+		{http.StatusUnauthorized, mPagerDuty.ErrUnauthorized},
+		{http.StatusForbidden, mPagerDuty.ErrForbidden},
+		{http.StatusNotFound, mPagerDuty.ErrNotFound},
+		{http.StatusConflict, mPagerDuty.ErrConflict},
+		{http.StatusTooManyRequests, mPagerDuty.ErrRateLimited},
+		{http.StatusInternalServerError, nil},
+	}
+
+	for _, test := range tests {
+		server, _ := failingServer(1, test.status, nil, nil)
+		mPD := liveClientForServer(t, server, mPagerDuty.WithRetryPolicy(mPagerDuty.NoRetry))
+
+		_, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+		server.Close()
+
+		var apiErr *mPagerDuty.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, test.status, apiErr.StatusCode)
+
+		var pdErr pagerduty.APIError
+		assert.True(t, errors.As(err, &pdErr))
+		assert.Equal(t, test.status, pdErr.StatusCode)
+
+		if test.expected != nil {
+			assert.True(t, errors.Is(err, test.expected), "status %d", test.status)
+		}
+		for _, sentinel := range []error{mPagerDuty.ErrInvalidArgument, mPagerDuty.ErrUnauthorized, mPagerDuty.ErrForbidden,
+			mPagerDuty.ErrNotFound, mPagerDuty.ErrConflict, mPagerDuty.ErrRateLimited} {
+			if sentinel != test.expected {
+				assert.False(t, errors.Is(err, sentinel), "status %d matched %v", test.status, sentinel)
+			}
+		}
+	}
+}
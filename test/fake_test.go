@@ -0,0 +1,178 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakePDClientIsStateful(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+	since := "2030-01-01T00:00:00Z"
+	until := "2030-01-08T00:00:00Z"
+
+	// overrides
+	overrides, err := mPD.GetOverrides("PUHMCXV", since, until, false)
+	assert.Nil(t, err)
+	assert.Empty(t, overrides.Overrides)
+
+	override, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-02T00:00:00Z", "2030-01-10T00:00:00Z")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, override.ID)
+
+	overrides, err = mPD.GetOverrides("PUHMCXV", since, until, false)
+	assert.Nil(t, err)
+	assert.Len(t, overrides.Overrides, 1)
+	assert.Equal(t, override.ID, overrides.Overrides[0].ID)
+	assert.Equal(t, until, overrides.Overrides[0].End)
+
+	overrides, err = mPD.GetOverrides("PUHMCXV", since, until, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "2030-01-10T00:00:00Z", overrides.Overrides[0].End)
+
+	assert.Nil(t, mPD.RemoveOverride("PUHMCXV", override.ID))
+	overrides, err = mPD.GetOverrides("PUHMCXV", since, until, false)
+	assert.Nil(t, err)
+	assert.Empty(t, overrides.Overrides)
+
+	err = mPD.RemoveOverride("PUHMCXV", override.ID)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	_, err = mPD.CreateOverride("PUHMCXV", "PJ6XOVE", "2030-01-10T00:00:00Z", "2030-01-02T00:00:00Z")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+
+	// incidents
+	incident, err := mPD.CreateIncident("The server is on fire", "P03NRF0", "", "", "PGPQHZF")
+	assert.Nil(t, err)
+	assert.Equal(t, "triggered", incident.Status)
+	assert.Equal(t, "high", incident.Urgency)
+
+	incidents, err := mPD.GetIndicentsByEscalationPolicy("PGPQHZF", -30)
+	assert.Nil(t, err)
+	assert.Len(t, incidents, 1)
+	assert.Equal(t, incident.ID, incidents[0].ID)
+
+	summary, err := mPD.SearchIncidentLogs(incident.ID, "trigger_log_entry")
+	assert.Nil(t, err)
+	assert.Equal(t, "Triggered through the API.", *summary)
+
+	_, err = mPD.SearchIncidentLogs("Q0000000000000", "trigger_log_entry")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// seeded incidents keep their log entries
+	summary, err = mPD.SearchIncidentLogs("Q3XZW6AK6GZ3TZ", "resolve_log_entry")
+	assert.Nil(t, err)
+	assert.Equal(t, "Resolved through the API.", *summary)
+
+	// escalation policies
+	policy, err := mPD.UpdateEscalationPolicy("P23N6LT", "PJ6XOVE", "P39MEWZ", "P83EOFI", []pagerduty.APIObject{{ID: "P273W1N", Type: "user_reference"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "INCY DEV Escalation Policy", policy.Name)
+	assert.Len(t, policy.EscalationRules, 2)
+	assert.Equal(t, "P39MEWZ", policy.Services[0].ID)
+
+	_, err = mPD.UpdateEscalationPolicy("PNOSUCH", "PJ6XOVE", "", "", []pagerduty.APIObject{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// every client has its own store
+	incidents, err = (&mPagerDuty.FakePDClient{}).GetIndicentsByEscalationPolicy("PGPQHZF", -30)
+	assert.Nil(t, err)
+	assert.Empty(t, incidents)
+}
+
+func TestFakePDClientLookups(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+
+	id, tz, err := mPD.GetScheduleIDbyName("caleb young testing")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUHMCXV", id)
+	assert.Equal(t, "America/Denver", tz)
+
+	id, _, err = mPD.GetScheduleIDbyName("No Such Schedule")
+	assert.Nil(t, err)
+	assert.Empty(t, id)
+
+	users, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{Query: "kalandarov"})
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+	users, err = mPD.ListAllUsers(pagerduty.ListUsersOptions{TeamIDs: []string{"PNOTEAM"}})
+	assert.Nil(t, err)
+	assert.Empty(t, users)
+
+	ids, err := mPD.GetUsersIDsByNames([]string{"Timur Kalandarov", "Nobody At All"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PJ6XOVE"}, ids)
+
+	tags, err := mPD.ListAllTags(pagerduty.ListTagOptions{Query: "gs"})
+	assert.Nil(t, err)
+	assert.Len(t, tags, 1)
+	assert.Equal(t, "P74RRGF", tags[0].ID)
+
+	policies, err := mPD.GetEscalationPoliciesByTag("P74RRGF")
+	assert.Nil(t, err)
+	assert.Len(t, policies.EscalationPolicies, 3)
+	_, err = mPD.GetEscalationPoliciesByTag("PNOSUCH")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	onCalls, err := mPD.GetOnCallsByScheduleIDs([]string{"PUHMCXV"})
+	assert.Nil(t, err)
+	assert.Len(t, onCalls, 1)
+	onCalls, err = mPD.GetOnCallsByScheduleIDs([]string{"PUY4P9O"})
+	assert.Nil(t, err)
+	assert.Empty(t, onCalls)
+
+	// seeded incidents are older than any relative time range
+	incidents, err := mPD.SearchIncidents("", -30)
+	assert.Nil(t, err)
+	assert.Empty(t, incidents)
+}
+
+func TestFakePDClientCopies(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	fixtures.Users = append(fixtures.Users, builder.User("PUSER03").Name("Alan Turing").Team("PTEAM01", "Platform").Build())
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	at := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// the fixtures the fake was made from do not share anything with it
+	fixtures.Users[2].Teams[0].ID = "MUTATED"
+	fixtures.EscalationPolicies[0].EscalationRules[0].Targets[0].ID = "MUTATED"
+
+	// nor do the results it hands out
+	user, err := mPD.GetUserByID("PUSER03", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "PTEAM01", user.Teams[0].ID)
+	user.Teams[0].ID = "MUTATED"
+	users, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{TeamIDs: []string{"PTEAM01"}})
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+	users[0].Teams[0].ID = "MUTATED"
+	user, err = mPD.GetUserByID("PUSER03", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "PTEAM01", user.Teams[0].ID)
+
+	levels, err := mPD.GetOnCallsAt(nil, []string{"PPOLICY1"}, at)
+	assert.Nil(t, err)
+	assert.Len(t, levels, 1)
+
+	// nor does what it is updated with, or the update it returns
+	targets := []pagerduty.APIObject{{ID: "PSCHED1", Type: "schedule_reference"}}
+	policy, err := mPD.UpdateEscalationPolicy("PPOLICY1", "PUSER01", "", "", targets)
+	assert.Nil(t, err)
+	targets[0].ID = "MUTATED"
+	for r := range policy.EscalationRules {
+		for i := range policy.EscalationRules[r].Targets {
+			policy.EscalationRules[r].Targets[i].ID = "MUTATED"
+		}
+	}
+	levels, err = mPD.GetOnCallsAt(nil, []string{"PPOLICY1"}, at)
+	assert.Nil(t, err)
+	assert.Len(t, levels, 2)
+}
@@ -0,0 +1,107 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultRules(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+	mPD.SetFaults(mPagerDuty.Faults{Rules: []mPagerDuty.FaultRule{
+		{Method: "GetUserByID", Fault: mPagerDuty.FaultServerError, Nth: 2},
+		{Method: "ListAllTags", Fault: mPagerDuty.FaultRateLimited, Times: 2},
+		{Method: "GetScheduleIDbyName", Fault: mPagerDuty.FaultTimeout},
+		{Method: "SearchIncidentLogs", Fault: mPagerDuty.FaultNotFound},
+	}})
+
+	// Nth
+	_, err := mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	_, err = mPD.GetUserByIDWithContext(context.Background(), "PJ6XOVE", pagerduty.GetUserOptions{})
+	var apiErr *mPagerDuty.APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 500, apiErr.StatusCode)
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+
+	// calls the fake makes itself do not count
+	_, err = mPD.GetIndicentsByTag("GSOC", -30)
+	assert.Nil(t, err)
+
+	// Times
+	for i := 0; i < 2; i++ {
+		_, err = mPD.ListAllTags(pagerduty.ListTagOptions{})
+		assert.True(t, errors.Is(err, mPagerDuty.ErrRateLimited))
+	}
+	_, err = mPD.ListAllTags(pagerduty.ListTagOptions{})
+	assert.Nil(t, err)
+
+	// fault kinds
+	_, _, err = mPD.GetScheduleIDbyName("Caleb Young TESTING")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	_, err = mPD.SearchIncidentLogs("Q3XZW6AK6GZ3TZ", "resolve_log_entry")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// clearing the rules
+	mPD.SetFaults(mPagerDuty.Faults{})
+	_, _, err = mPD.GetScheduleIDbyName("Caleb Young TESTING")
+	assert.Nil(t, err)
+}
+
+func TestFaultLatency(t *testing.T) {
+	mPD := &mPagerDuty.FakePDClient{}
+	mPD.SetFaults(mPagerDuty.Faults{Rules: []mPagerDuty.FaultRule{
+		{Latency: 20 * time.Millisecond, Times: 1},
+		{Method: "GetUserIDbyName", Latency: time.Hour},
+	}})
+
+	start := time.Now()
+	_, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = mPD.GetUserIDbyNameWithContext(ctx, "Timur Kalandarov")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestFaultProbabilityIsRepeatable(t *testing.T) {
+	outcomes := func() []bool {
+		mPD := &mPagerDuty.FakePDClient{}
+		mPD.SetFaults(mPagerDuty.Faults{
+			Rules: []mPagerDuty.FaultRule{{Fault: mPagerDuty.FaultServerError, Probability: 0.5}},
+			Seed:  42,
+		})
+		var failed []bool
+		for i := 0; i < 50; i++ {
+			_, err := mPD.ListAllTags(pagerduty.ListTagOptions{})
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+
+	first := outcomes()
+	assert.Equal(t, first, outcomes())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestWithFaults(t *testing.T) {
+	mPD, err := mPagerDuty.GetMPagerDutyClient("",
+		mPagerDuty.WithMode(mPagerDuty.ModeFake),
+		mPagerDuty.WithFaults(mPagerDuty.Faults{Rules: []mPagerDuty.FaultRule{{Fault: mPagerDuty.FaultRateLimited, Nth: 1}}}))
+	assert.Nil(t, err)
+
+	_, err = mPD.GetUserIDbyName("Timur Kalandarov")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrRateLimited))
+	_, err = mPD.GetUserIDbyName("Timur Kalandarov")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,109 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml", "testdata/incidents.json")
+	assert.Nil(t, err)
+	assert.Len(t, fixtures.Users, 2)
+	assert.Len(t, fixtures.Incidents, 1)
+
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	id, tz, err := mPD.GetScheduleIDbyName("Platform Primary")
+	assert.Nil(t, err)
+	assert.Equal(t, "PSCHED1", id)
+	assert.Equal(t, "Europe/London", tz)
+
+	userID, err := mPD.GetUserIDbyName("Grace Hopper")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER02", userID)
+
+	overrides, err := mPD.GetOverrides("PSCHED1", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z", false)
+	assert.Nil(t, err)
+	assert.Len(t, overrides.Overrides, 1)
+	assert.Equal(t, "QOVERRIDE1", overrides.Overrides[0].ID)
+
+	onCalls, err := mPD.GetOnCallsByScheduleIDs([]string{"PSCHED1"})
+	assert.Nil(t, err)
+	assert.Len(t, onCalls, 1)
+	assert.Equal(t, "PUSER01", onCalls[0].User.ID)
+
+	policies, err := mPD.GetEscalationPoliciesByTag("PTAG001")
+	assert.Nil(t, err)
+	assert.Len(t, policies.EscalationPolicies, 1)
+
+	summary, err := mPD.SearchIncidentLogs("QINCIDENT1", "resolve_log_entry")
+	assert.Nil(t, err)
+	assert.Equal(t, "Resolved by Ada Lovelace.", *summary)
+
+	// loaded fixtures replace the default world instead of adding to it
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// changes are never written back into the fixtures
+	_, err = mPD.CreateOverride("PSCHED1", "PUSER01", "2030-01-01T18:00:00Z", "2030-01-01T20:00:00Z")
+	assert.Nil(t, err)
+	assert.Len(t, fixtures.Overrides["PSCHED1"], 1)
+}
+
+func TestWithFixtures(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+
+	mPD, err := mPagerDuty.GetMPagerDutyClient("", mPagerDuty.WithMode(mPagerDuty.ModeFake), mPagerDuty.WithFixtures(fixtures))
+	assert.Nil(t, err)
+	userID, err := mPD.GetUserIDbyName("Ada Lovelace")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER01", userID)
+
+	fixtures.Users = append(fixtures.Users, fixtures.Users[0])
+	_, err = mPagerDuty.GetMPagerDutyClient("", mPagerDuty.WithMode(mPagerDuty.ModeFake), mPagerDuty.WithFixtures(fixtures))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+}
+
+func TestDefaultFixtures(t *testing.T) {
+	assert.Nil(t, mPagerDuty.DefaultFixtures().Validate())
+}
+
+func TestLoadMalformedFixtures(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown_field.yaml", "users:\n  - id: PUSER01\n    nmae: Ada Lovelace\n"},
+		{"syntax.yaml", "users: [unterminated\n"},
+		{"syntax.json", `{"users": [`},
+		{"missing_name.json", `{"users": [{"id": "PUSER01"}]}`},
+		{"duplicate_id.yaml", "users:\n  - {id: PUSER01, name: Ada Lovelace}\n  - {id: PUSER01, name: Grace Hopper}\n"},
+		{"unknown_time_zone.yaml", "schedules:\n  - {id: PSCHED1, name: Primary, time_zone: Mars/Olympus_Mons}\n"},
+		{"unknown_user.yaml", "schedules:\n  - {id: PSCHED1, name: Primary}\noverrides:\n  PSCHED1:\n    - {id: Q1, start: 2030-01-01T00:00:00Z, end: 2030-01-02T00:00:00Z, user: {id: PNOSUCH}}\n"},
+		{"reversed_override.yaml", "users:\n  - {id: PUSER01, name: Ada Lovelace}\nschedules:\n  - {id: PSCHED1, name: Primary}\noverrides:\n  PSCHED1:\n    - {id: Q1, start: 2030-01-02T00:00:00Z, end: 2030-01-01T00:00:00Z, user: {id: PUSER01}}\n"},
+		{"unknown_tag.yaml", "tagged_escalation_policies:\n  PNOSUCH: []\n"},
+		{"bad_date.json", `{"incidents": [{"id": "Q1", "title": "Disk full", "created_at": "yesterday"}]}`},
+		{"unknown_incident.json", `{"log_entries": {"QNOSUCH": [{"type": "trigger_log_entry"}]}}`},
+		{"fixtures.toml", "users = []\n"},
+	}
+
+	for _, test := range tests {
+		path := filepath.Join(t.TempDir(), test.name)
+		assert.Nil(t, os.WriteFile(path, []byte(test.content), 0o600))
+
+		_, err := mPagerDuty.LoadFixtures(path)
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), test.name)
+	}
+
+	_, err := mPagerDuty.LoadFixtures(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.NotNil(t, err)
+}
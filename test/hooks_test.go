@@ -0,0 +1,130 @@
+package mPagerDuty_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTracer keeps the name and attributes of every span it started
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attributes ...mPagerDuty.Attribute) (context.Context, mPagerDuty.Span) {
+	span := &recordedSpan{name: name, attributes: map[string]interface{}{}}
+	span.SetAttributes(attributes...)
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return ctx, span
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...mPagerDuty.Attribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+
+func TestHooks(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pagerduty.ListUsersResponse{
+			APIListObject: pagerduty.APIListObject{Offset: uint(offset), Limit: 1, More: offset < 1},
+			Users:         []pagerduty.User{{APIObject: pagerduty.APIObject{ID: "P" + strconv.Itoa(offset)}}},
+		})
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var events []mPagerDuty.Event
+	hook := mPagerDuty.HookFunc(func(ctx context.Context, event mPagerDuty.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	})
+	tracer := &recordingTracer{}
+	var logs bytes.Buffer
+
+	config := mPagerDuty.DefaultConfig()
+	config.PageSize = 1
+	mPD := liveClientForServer(t, server,
+		mPagerDuty.WithConfig(config),
+		mPagerDuty.WithRetryPolicy(fastRetries),
+		mPagerDuty.WithHooks(hook, mPagerDuty.LogHook(log.New(&logs, "", 0))),
+		mPagerDuty.WithTracer(tracer))
+
+	users, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{Query: "timur"})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+
+	kinds := []mPagerDuty.EventKind{}
+	for _, event := range events {
+		kinds = append(kinds, event.Kind)
+		assert.Equal(t, "ListAllUsers", event.Method)
+	}
+	assert.Equal(t, []mPagerDuty.EventKind{
+		mPagerDuty.EventMethodStart,
+		mPagerDuty.EventRequest,
+		mPagerDuty.EventRequest,
+		mPagerDuty.EventRetry,
+		mPagerDuty.EventRequest,
+		mPagerDuty.EventMethodEnd,
+	}, kinds)
+	assert.Contains(t, events[0].Args, "timur")
+	assert.Equal(t, 1, events[1].Page)
+	assert.Equal(t, http.StatusOK, events[1].StatusCode)
+	assert.Equal(t, "/users", events[1].Path)
+	assert.Equal(t, 2, events[2].Page)
+	assert.Equal(t, http.StatusServiceUnavailable, events[2].StatusCode)
+	assert.Equal(t, 1, events[3].Attempt)
+	assert.NotNil(t, events[3].Err)
+	assert.Equal(t, 2, events[4].Attempt)
+	assert.Nil(t, events[5].Err)
+	assert.Contains(t, logs.String(), "event=retry method=ListAllUsers")
+
+	assert.Len(t, tracer.spans, 4)
+	assert.Equal(t, "mPagerDuty.ListAllUsers", tracer.spans[0].name)
+	assert.Equal(t, "HTTP GET", tracer.spans[1].name)
+	assert.Equal(t, http.StatusServiceUnavailable, tracer.spans[2].attributes["http.status_code"])
+	for _, span := range tracer.spans {
+		assert.True(t, span.ended)
+	}
+
+	// invalid arguments end the method span with the error
+	_, err = mPD.GetUserByID("  ", pagerduty.GetUserOptions{})
+	assert.NotNil(t, err)
+	last := tracer.spans[len(tracer.spans)-1]
+	assert.Equal(t, "mPagerDuty.GetUserByID", last.name)
+	assert.Equal(t, err, last.err)
+	assert.Equal(t, err, events[len(events)-1].Err)
+}
@@ -1,6 +1,8 @@
 package mPagerDuty_test
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -89,7 +91,12 @@ func TestGetUserIDbyName(t *testing.T) {
 	}{
 		{
 			"Timur Kalandarov",
-			"PUHMCXV",
+			"PJ6XOVE",
+			false,
+		},
+		{
+			"Nobody At All",
+			"",
 			false,
 		},
 		{
@@ -101,7 +108,7 @@ func TestGetUserIDbyName(t *testing.T) {
 
 	mPD := mPagerDuty.FakePDClient{}
 	for _, test := range tests {
-		result, _, err := mPD.GetScheduleIDbyName(test.name)
+		result, err := mPD.GetUserIDbyName(test.name)
 		if test.expectedErr {
 			assert.NotNil(t, err)
 		} else {
@@ -118,7 +125,7 @@ func TestGetUserByID(t *testing.T) {
 		expectedErr      bool
 	}{
 		{
-			"PUHMCXV",
+			"PJ6XOVE",
 			"Timur Kalandarov",
 			false,
 		},
@@ -128,9 +135,9 @@ func TestGetUserByID(t *testing.T) {
 			true,
 		},
 		{
-			"    TEST",
-			"Timur Kalandarov",
-			false,
+			"    TEST", // no such user
+			"",
+			true,
 		},
 	}
 
@@ -152,27 +159,30 @@ func TestGetOverrides(t *testing.T) {
 
 	tests := []struct {
 		scheduleID  string
-		userName    string
 		expectedErr bool
 	}{
 		{
-			"P10QVCS",
-			"Timur Kalandarov",
+			"PUHMCXV",
 			false,
 		},
 		{
-			"   P10QVCS",
-			"Timur Kalandarov",
-			false,
+			"   PUHMCXV", // IDs are not trimmed, so there is no such schedule
+			true,
+		},
+		{
+			"P10QVCS",
+			true,
 		},
 		{
 			"    ",
-			"",
 			true,
 		},
 	}
 
 	mPD := mPagerDuty.FakePDClient{}
+	_, err := mPD.CreateOverride("PUHMCXV", "PJ6XOVE", time.Now().Add(time.Hour).Format(time.RFC3339), time.Now().Add(2*time.Hour).Format(time.RFC3339))
+	assert.Nil(t, err)
+
 	for _, test := range tests {
 		resp, err := mPD.GetOverrides(test.scheduleID, minTime, maxTime, false)
 		if test.expectedErr {
@@ -190,14 +200,24 @@ func TestCreateOverride(t *testing.T) {
 
 	tests := []struct {
 		scheduleID  string
-		userName    string
+		userID      string
 		expectedErr bool
 	}{
 		{
-			"P10QVCS",
-			"Timur Kalandarov",
+			"PUHMCXV",
+			"PJ6XOVE",
 			false,
 		},
+		{
+			"P10QVCS", // no such schedule
+			"PJ6XOVE",
+			true,
+		},
+		{
+			"PUHMCXV",
+			"PWKNFGT", // no such user
+			true,
+		},
 		{
 			"  ",
 			"",
@@ -217,12 +237,13 @@ func TestCreateOverride(t *testing.T) {
 
 	mPD := mPagerDuty.FakePDClient{}
 	for _, test := range tests {
-		override, err := mPD.CreateOverride(test.userName, test.scheduleID, minTime, maxTime)
+		override, err := mPD.CreateOverride(test.scheduleID, test.userID, minTime, maxTime)
 		if test.expectedErr {
 			assert.NotNil(t, err)
 		} else {
 			assert.Nil(t, err)
 			assert.NotNil(t, override)
+			assert.Equal(t, test.userID, override.User.ID)
 		}
 	}
 }
@@ -234,10 +255,15 @@ func TestRemoveOverride(t *testing.T) {
 		expectedErr bool
 	}{
 		{
-			"P10QVCS",
-			"Timur Kalandarov",
+			"PUHMCXV",
+			"Q1NF06I8X9HJAK",
 			false,
 		},
+		{
+			"PUHMCXV",
+			"Q1NF06I8X9HJAK", // already removed
+			true,
+		},
 		{
 			" TEST",
 			"",
@@ -281,6 +307,9 @@ func TestGetIndicentsByEP(t *testing.T) {
 	}
 
 	mPD := mPagerDuty.FakePDClient{}
+	_, err := mPD.CreateIncident("The server is on fire", "P03NRF0", "high", "", "PXYKJ4K")
+	assert.Nil(t, err)
+
 	for _, test := range tests {
 		incidents, err := mPD.GetIndicentsByEscalationPolicy(test.escalationPolicyID, -30)
 		if test.expectedErr {
@@ -294,13 +323,17 @@ func TestGetIndicentsByEP(t *testing.T) {
 
 func TestGetIndicentsByTag(t *testing.T) {
 	tests := []struct {
-		tagID       string
+		tagName     string
 		expectedErr bool
 	}{
 		{
-			"P74RRGF", // GSOC tag
+			"GSOC",
 			false,
 		},
+		{
+			"P74RRGF", // tags are looked up by label, not by ID
+			true,
+		},
 		{
 			"",
 			true,
@@ -312,8 +345,11 @@ func TestGetIndicentsByTag(t *testing.T) {
 	}
 
 	mPD := mPagerDuty.FakePDClient{}
+	_, err := mPD.CreateIncident("The server is on fire", "P03NRF0", "high", "", "PP2PMMD") // escalation policy tagged GSOC
+	assert.Nil(t, err)
+
 	for _, test := range tests {
-		incidents, err := mPD.GetIndicentsByTag(test.tagID, -30)
+		incidents, err := mPD.GetIndicentsByTag(test.tagName, -30)
 		if test.expectedErr {
 			assert.NotNil(t, err)
 		} else {
@@ -463,7 +499,7 @@ func TestUpdateEscalationPolicy(t *testing.T) {
 			false,
 		},
 		{
-			"   P23N6LT", // INCY DEV Escalation Policy
+			"   P23N6LT", // IDs are not trimmed, so there is no such escalation policy
 			"PWKNFGT",    // Timur Kalandarov
 			"  ",
 			"",
@@ -473,7 +509,7 @@ func TestUpdateEscalationPolicy(t *testing.T) {
 					Type: "user_reference",
 				},
 			},
-			false,
+			true,
 		},
 		{
 			"",
@@ -523,6 +559,30 @@ func TestUpdateEscalationPolicy(t *testing.T) {
 	}
 }
 
+func TestCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	clients := []mPagerDuty.IMPagerDuty{&mPagerDuty.FakePDClient{}}
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken)
+	assert.Nil(t, err)
+	clients = append(clients, mPD)
+
+	for _, mPD := range clients {
+		_, err := mPD.GetOnCallsByScheduleIDsWithContext(ctx, []string{"PUY4P9O"})
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		_, err = mPD.ListAllUsersWithContext(ctx, pagerduty.ListUsersOptions{})
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		_, _, err = mPD.GetScheduleIDbyNameWithContext(ctx, "Timur Kalandarov")
+		assert.True(t, errors.Is(err, context.Canceled))
+
+		_, err = mPD.SearchIncidentsWithContext(ctx, "GSOC", -30)
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+}
+
 /* THE TESTS BELOW THIS COMMENT ARE FULL INTEGRATION TESTS AND WILL USE THE
    ACTUAL REAL TWITCH'S PAGERDUTY INSTANCE. BECAUSE OF THIS, THESE TESTS
    SHOULD NOT BE RUN FREQUENTLY AND HAVE A LINE AT THE BEGINNING OF THEM
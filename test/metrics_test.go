@@ -0,0 +1,54 @@
+package mPagerDuty_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	server, _ := failingServer(1, http.StatusInternalServerError, nil, pagerduty.ListUsersResponse{
+		Users: []pagerduty.User{{APIObject: pagerduty.APIObject{ID: "PJ6XOVE"}, Name: "Timur Kalandarov"}},
+	})
+	defer server.Close()
+
+	metrics := mPagerDuty.NewMetrics()
+	mPD := liveClientForServer(t, server, mPagerDuty.WithRetryPolicy(fastRetries), mPagerDuty.WithMetrics(metrics))
+
+	_, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	_, err = mPD.GetUserByID("", pagerduty.GetUserOptions{})
+	assert.NotNil(t, err)
+
+	scraper := httptest.NewServer(metrics.Handler())
+	defer scraper.Close()
+	resp, err := http.Get(scraper.URL)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	text := string(body)
+
+	assert.Contains(t, resp.Header.Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, text, "# TYPE mpagerduty_method_calls counter\n")
+	assert.Contains(t, text, `mpagerduty_method_calls_total{method="ListAllUsers",result="success"} 1`)
+	assert.Contains(t, text, `mpagerduty_method_calls_total{method="GetUserByID",result="error"} 1`)
+	assert.Contains(t, text, `mpagerduty_method_duration_seconds_count{method="ListAllUsers"} 1`)
+	assert.Contains(t, text, `mpagerduty_method_duration_seconds_bucket{method="ListAllUsers",le="0.005"} `)
+	assert.Contains(t, text, `mpagerduty_method_duration_seconds_bucket{method="ListAllUsers",le="1.0"} `)
+	assert.Contains(t, text, `mpagerduty_method_duration_seconds_bucket{method="ListAllUsers",le="10.0"} `)
+	assert.NotContains(t, text, `le="1"`)
+	assert.Contains(t, text, `mpagerduty_method_duration_seconds_bucket{method="ListAllUsers",le="+Inf"} 1`)
+	assert.Contains(t, text, `mpagerduty_http_requests_total{method="ListAllUsers",status="500"} 1`)
+	assert.Contains(t, text, `mpagerduty_http_requests_total{method="ListAllUsers",status="200"} 1`)
+	assert.Contains(t, text, `mpagerduty_http_request_duration_seconds_count{method="ListAllUsers"} 2`)
+	assert.Contains(t, text, `mpagerduty_retries_total{method="ListAllUsers"} 1`)
+	assert.Contains(t, text, `mpagerduty_pages_total{method="ListAllUsers"} 1`)
+	assert.Regexp(t, "# EOF\n$", text)
+}
@@ -0,0 +1,86 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/mock"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockT collects what a mock reports, and runs its cleanups on demand
+type mockT struct {
+	recordingT
+	cleanups []func()
+}
+
+func (t *mockT) Cleanup(cleanup func()) {
+	t.cleanups = append(t.cleanups, cleanup)
+}
+
+func (t *mockT) finish() {
+	for _, cleanup := range t.cleanups {
+		cleanup()
+	}
+}
+
+func TestMockExpectations(t *testing.T) {
+	mPD := mock.NewMockPDClient(t)
+	user := &pagerduty.User{APIObject: pagerduty.APIObject{ID: "PUSER01"}, Name: "Ada Lovelace"}
+	mPD.On("GetUserByID", "PUSER01", mPagerDuty.Anything).Return(user, nil)
+	mPD.On("CreateOverride", "PSCHED1", "PUSER01", mPagerDuty.Anything, mPagerDuty.Anything).
+		Return(nil, mPagerDuty.ErrRateLimited).Once()
+	mPD.On("CreateOverride", "PSCHED1", "PUSER01", mPagerDuty.Anything, mPagerDuty.Anything).
+		Return(&pagerduty.Override{ID: "QOVERRIDE1"}, nil).Once()
+	mPD.On("RemoveOverride").Return(nil).Maybe()
+
+	var client mPagerDuty.IMPagerDuty = mPD
+	got, err := client.GetUserByID("PUSER01", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, user, got)
+	got, err = client.GetUserByIDWithContext(context.Background(), "PUSER01", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, user, got)
+
+	// expectations answer in the order they were declared
+	_, err = client.CreateOverride("PSCHED1", "PUSER01", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrRateLimited))
+	override, err := client.CreateOverride("PSCHED1", "PUSER01", "2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, "QOVERRIDE1", override.ID)
+}
+
+func TestMockFailures(t *testing.T) {
+	recorder := &mockT{}
+	mPD := mock.NewMockPDClient(recorder)
+	mPD.On("GetUserIDbyName", "Ada Lovelace").Return("PUSER01", nil).Times(2)
+	mPD.On("ListAllTags").Return(nil, nil)
+	mPD.On("GetScheduleIDbyName").Return("PSCHED1", nil)
+
+	// unexpected calls return zero values
+	id, err := mPD.GetUserIDbyName("Grace Hopper")
+	assert.Empty(t, id)
+	assert.Nil(t, err)
+	assert.Len(t, recorder.failures, 1)
+	assert.Contains(t, recorder.failures[0], `unexpected call GetUserIDbyName("Grace Hopper")`)
+
+	// expectations returning the wrong values
+	_, _, err = mPD.GetScheduleIDbyName("Platform Primary")
+	assert.Nil(t, err)
+	assert.Len(t, recorder.failures, 2)
+	assert.Contains(t, recorder.failures[1], "GetScheduleIDbyName returns 3 values")
+
+	id, err = mPD.GetUserIDbyName("Ada Lovelace")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER01", id)
+
+	// unmet expectations are reported when the test ends
+	recorder.finish()
+	assert.Len(t, recorder.failures, 4)
+	assert.Contains(t, recorder.failures[2], `expected GetUserIDbyName("Ada Lovelace") to be called 2 times, but it was called 1 times`)
+	assert.Contains(t, recorder.failures[3], "expected ListAllTags() to be called, but it was not")
+}
@@ -0,0 +1,112 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientMode(t *testing.T) {
+	tests := []struct {
+		jenkins      string
+		pdMode       string
+		options      []mPagerDuty.ClientOption
+		expectedMode mPagerDuty.Mode
+		expectedErr  bool
+	}{
+		{"true", "", nil, mPagerDuty.ModeLive, false},
+		{"true", "", []mPagerDuty.ClientOption{mPagerDuty.WithModeFromEnv()}, mPagerDuty.ModeFake, false},
+		{"false", "", []mPagerDuty.ClientOption{mPagerDuty.WithModeFromEnv()}, mPagerDuty.ModeLive, false},
+		{"true", "", []mPagerDuty.ClientOption{mPagerDuty.WithModeFromEnv(), mPagerDuty.WithMode(mPagerDuty.ModeLive)}, mPagerDuty.ModeLive, false},
+		{"false", "fake", []mPagerDuty.ClientOption{mPagerDuty.WithModeFromEnv()}, mPagerDuty.ModeFake, false},
+		{"false", "fake", nil, mPagerDuty.ModeLive, false},
+		{"false", "bogus", []mPagerDuty.ClientOption{mPagerDuty.WithModeFromEnv()}, mPagerDuty.ModeLive, true},
+		{"false", "", []mPagerDuty.ClientOption{mPagerDuty.WithMode(mPagerDuty.ModeFake)}, mPagerDuty.ModeFake, false},
+		{"false", "", []mPagerDuty.ClientOption{mPagerDuty.WithMode(mPagerDuty.ModeEmulator)}, mPagerDuty.ModeEmulator, true},
+		{"false", "", []mPagerDuty.ClientOption{mPagerDuty.WithMode(mPagerDuty.ModeEmulator), mPagerDuty.WithAPIEndpoint("http://127.0.0.1:8080")}, mPagerDuty.ModeEmulator, false},
+	}
+
+	for _, test := range tests {
+		t.Setenv("RUNNING_IN_JENKINS", test.jenkins)
+		t.Setenv("LOCAL_DEV_TESTING", "")
+		t.Setenv("PD_MODE", test.pdMode)
+
+		mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken, test.options...)
+		if test.expectedErr {
+			assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+			continue
+		}
+		assert.Nil(t, err)
+
+		mode, ok := mPagerDuty.ModeOf(mPD)
+		assert.True(t, ok)
+		assert.Equal(t, test.expectedMode, mode)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range []mPagerDuty.Mode{mPagerDuty.ModeLive, mPagerDuty.ModeFake, mPagerDuty.ModeReplay, mPagerDuty.ModeEmulator} {
+		parsed, err := mPagerDuty.ParseMode(mode.String())
+		assert.Nil(t, err)
+		assert.Equal(t, mode, parsed)
+	}
+
+	_, err := mPagerDuty.ParseMode("staging")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument))
+}
+
+func TestFakeModeOptions(t *testing.T) {
+	var events []mPagerDuty.Event
+	hook := mPagerDuty.HookFunc(func(ctx context.Context, event mPagerDuty.Event) {
+		events = append(events, event)
+	})
+	tracer := &recordingTracer{}
+	config := mPagerDuty.DefaultConfig()
+	config.SchedulePrefix = "Caleb Young"
+	config.TeamIDs = []string{"PNOTEAM"}
+
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeFake),
+		mPagerDuty.WithConfig(config),
+		mPagerDuty.WithHooks(hook),
+		mPagerDuty.WithTracer(tracer))
+	assert.Nil(t, err)
+
+	// the configuration applies like it does to the live client
+	id, _, err := mPD.GetScheduleIDbyName("TESTING")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUHMCXV", id)
+	userID, err := mPD.GetUserIDbyName("Timur Kalandarov")
+	assert.Nil(t, err)
+	assert.Empty(t, userID, "the user is in none of the configured teams")
+
+	// and the methods are observed, without the calls the fake makes to itself
+	_, err = mPD.GetUserByID("PJ6XOVE", pagerduty.GetUserOptions{})
+	assert.Nil(t, err)
+	kinds := []mPagerDuty.EventKind{}
+	for _, event := range events {
+		kinds = append(kinds, event.Kind)
+	}
+	assert.Equal(t, []mPagerDuty.EventKind{
+		mPagerDuty.EventMethodStart, mPagerDuty.EventMethodEnd,
+		mPagerDuty.EventMethodStart, mPagerDuty.EventMethodEnd,
+		mPagerDuty.EventMethodStart, mPagerDuty.EventMethodEnd,
+	}, kinds)
+	assert.Equal(t, "GetUserByID", events[4].Method)
+	if assert.Len(t, tracer.spans, 3) {
+		assert.Equal(t, "mPagerDuty.GetUserByID", tracer.spans[2].name)
+		assert.True(t, tracer.spans[2].ended)
+	}
+
+	// a fake sends nothing a cassette could hold
+	_, err = mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeFake),
+		mPagerDuty.WithCassette(filepath.Join(t.TempDir(), "cassette.json")))
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%v", err)
+}
@@ -0,0 +1,138 @@
+package mPagerDuty_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnCallsAt(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	fake, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	at := func(day, hour int) time.Time { return time.Date(2030, 1, day, hour, 0, 0, 0, time.UTC) }
+	ada := func(start, end time.Time) mPagerDuty.OnCallUser {
+		return mPagerDuty.OnCallUser{UserID: "PUSER01", UserName: "Ada Lovelace", ScheduleID: "PSCHED1", ScheduleName: "Platform Primary", Start: start, End: end}
+	}
+	grace := func(start, end time.Time) mPagerDuty.OnCallUser {
+		return mPagerDuty.OnCallUser{UserID: "PUSER02", UserName: "Grace Hopper", ScheduleID: "PSCHED1", ScheduleName: "Platform Primary", Start: start, End: end}
+	}
+	level := func(level uint, users ...mPagerDuty.OnCallUser) mPagerDuty.OnCallLevel {
+		return mPagerDuty.OnCallLevel{EscalationPolicyID: "PPOLICY1", EscalationPolicyName: "Platform Escalation", Level: level, Users: users}
+	}
+
+	for name, mPD := range map[string]mPagerDuty.IMPagerDuty{"fake": fake, "emulator": newEmulatedClient(t)} {
+		// PSCHED1 hands over from Ada to Grace at midnight, and Grace overrides Ada on the first from 9 to 5
+		for _, expected := range []struct {
+			at     time.Time
+			levels []mPagerDuty.OnCallLevel
+		}{
+			{at(1, 6), []mPagerDuty.OnCallLevel{level(1, ada(at(1, 0), at(1, 9)))}},
+			{at(1, 12), []mPagerDuty.OnCallLevel{level(1, grace(at(1, 9), at(1, 17)))}},
+			{at(1, 17), []mPagerDuty.OnCallLevel{level(1, ada(at(1, 17), at(2, 0)))}},
+			{at(2, 6), []mPagerDuty.OnCallLevel{level(1, grace(at(2, 0), at(3, 0)))}},
+		} {
+			levels, err := mPD.GetOnCallsAt([]string{"PSCHED1"}, nil, expected.at)
+			assert.Nil(t, err, name)
+			assert.Equal(t, expected.levels, levels, "%s at %s", name, expected.at)
+			levels, err = mPD.GetOnCallsAt(nil, []string{"PPOLICY1"}, expected.at)
+			assert.Nil(t, err, name)
+			assert.Equal(t, expected.levels, levels, "%s at %s", name, expected.at)
+		}
+
+		levels, err := mPD.GetOnCallsAt([]string{"PNOSUCH"}, nil, at(1, 12))
+		assert.Nil(t, err, name)
+		assert.Empty(t, levels, name)
+
+		_, err = mPD.GetOnCallsAt(nil, nil, at(1, 12))
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: no IDs: %v", name, err)
+		_, err = mPD.GetOnCallsAt([]string{"PSCHED1"}, []string{" "}, at(1, 12))
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: blank ID: %v", name, err)
+		_, err = mPD.GetOnCallsAt([]string{"PSCHED1"}, nil, time.Time{})
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: no time: %v", name, err)
+
+		// Ada is on call permanently at the first level, and the schedule takes the second
+		_, err = mPD.UpdateEscalationPolicy("PPOLICY1", "PUSER01", "", "", []pagerduty.APIObject{{ID: "PSCHED1", Type: "schedule_reference"}})
+		assert.Nil(t, err, name)
+		levels, err = mPD.GetOnCallsAt(nil, []string{"PPOLICY1"}, at(1, 12))
+		assert.Nil(t, err, name)
+		assert.Equal(t, []mPagerDuty.OnCallLevel{
+			level(1, mPagerDuty.OnCallUser{UserID: "PUSER01", UserName: "Ada Lovelace"}),
+			level(2, grace(at(1, 9), at(1, 17))),
+		}, levels, name)
+		levels, err = mPD.GetOnCallsAt([]string{"PSCHED1"}, nil, at(1, 12))
+		assert.Nil(t, err, name)
+		assert.Equal(t, []mPagerDuty.OnCallLevel{level(2, grace(at(1, 9), at(1, 17)))}, levels, "%s: users targeted directly are left out", name)
+	}
+}
+
+func TestOnCallsWithOptionsRange(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	// without a range, the stored on-calls are returned
+	response, err := mPD.GetOnCallsWithOptions(&pagerduty.ListOnCallOptions{ScheduleIDs: []string{"PSCHED1"}})
+	assert.Nil(t, err)
+	assert.Len(t, response.OnCalls, 1)
+
+	// with one, they are computed from the schedule
+	response, err = mPD.GetOnCallsWithOptions(&pagerduty.ListOnCallOptions{
+		ScheduleIDs: []string{"PSCHED1"},
+		Since:       "2030-01-01T08:00:00Z",
+		Until:       "2030-01-02T08:00:00Z",
+	})
+	assert.Nil(t, err)
+	var users []string
+	for _, onCall := range response.OnCalls {
+		users = append(users, onCall.User.ID+" "+onCall.Start+" "+onCall.End)
+	}
+	assert.Equal(t, []string{
+		"PUSER01 2030-01-01T00:00:00Z 2030-01-01T09:00:00Z",
+		"PUSER02 2030-01-01T09:00:00Z 2030-01-01T17:00:00Z",
+		"PUSER01 2030-01-01T17:00:00Z 2030-01-02T00:00:00Z",
+		"PUSER02 2030-01-02T00:00:00Z 2030-01-03T00:00:00Z",
+	}, users)
+
+	_, err = mPD.GetOnCallsWithOptions(&pagerduty.ListOnCallOptions{Since: "yesterday"})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%v", err)
+}
+
+func TestOnCallsAtTimeZone(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pagerduty.ListOnCallsResponse{OnCalls: []pagerduty.OnCall{{
+			User:             pagerduty.User{APIObject: pagerduty.APIObject{ID: "PUSER01", Summary: "Ada Lovelace"}},
+			EscalationPolicy: pagerduty.EscalationPolicy{APIObject: pagerduty.APIObject{ID: "PPOLICY1"}},
+			EscalationLevel:  1,
+			Start:            "2030-07-01T01:00:00+01:00",
+			End:              "2030-07-02T01:00:00+01:00",
+		}}})
+	}))
+	defer server.Close()
+
+	config := mPagerDuty.DefaultConfig()
+	config.DefaultTimeZone = "Europe/London"
+	mPD := liveClientForServer(t, server, mPagerDuty.WithConfig(config))
+
+	// the on-calls are asked for in the configured time zone, and still compared as instants
+	levels, err := mPD.GetOnCallsAt(nil, []string{"PPOLICY1"}, time.Date(2030, 7, 1, 12, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Europe/London"}, query["time_zone"])
+	if assert.Len(t, levels, 1) && assert.Len(t, levels[0].Users, 1) {
+		assert.True(t, levels[0].Users[0].Start.Equal(time.Date(2030, 7, 1, 0, 0, 0, 0, time.UTC)))
+	}
+}
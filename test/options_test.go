@@ -0,0 +1,80 @@
+package mPagerDuty_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// liveClientForServer points an actual client at server
+func liveClientForServer(t *testing.T, server *httptest.Server, options ...mPagerDuty.ClientOption) mPagerDuty.IMPagerDuty {
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken, append([]mPagerDuty.ClientOption{mPagerDuty.WithAPIEndpoint(server.URL)}, options...)...)
+	assert.Nil(t, err)
+	return mPD
+}
+
+func TestClientOptions(t *testing.T) {
+	var userAgent, from string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		from = r.Header.Get("From")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"incident": pagerduty.Incident{APIObject: pagerduty.APIObject{ID: "Q1"}, Title: "The server is on fire"},
+		})
+	}))
+	defer server.Close()
+
+	mPD := liveClientForServer(t, server,
+		mPagerDuty.WithUserAgent("mpagerduty-test"),
+		mPagerDuty.WithFrom("oncall@example.com"),
+		mPagerDuty.WithHTTPClient(server.Client()))
+
+	incident, err := mPD.CreateIncident("The server is on fire", "P03NRF0", "high", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "Q1", incident.ID)
+	assert.Equal(t, "mpagerduty-test", userAgent)
+	assert.Equal(t, "oncall@example.com", from)
+}
+
+func TestPaginationHonorsMore(t *testing.T) {
+	const total = 250
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+		var users []pagerduty.User
+		for i := offset; i < offset+limit && i < total; i++ {
+			users = append(users, pagerduty.User{APIObject: pagerduty.APIObject{ID: "P" + strconv.Itoa(i)}})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pagerduty.ListUsersResponse{
+			APIListObject: pagerduty.APIListObject{Offset: uint(offset), Limit: uint(limit), More: offset+limit < total},
+			Users:         users,
+		})
+	}))
+	defer server.Close()
+
+	users, err := liveClientForServer(t, server).ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, users, total)
+	assert.Equal(t, "P249", users[total-1].ID)
+	assert.Equal(t, 3, requests)
+
+	requests = 0
+	users, err = liveClientForServer(t, server, mPagerDuty.WithMaxItems(120)).ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrTruncated), "%v", err)
+	assert.Nil(t, users)
+	assert.Equal(t, 2, requests, "paging stops once the cap is exceeded")
+}
@@ -0,0 +1,70 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"testing"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCappedClient returns a client of an emulator holding two users and two schedules,
+// which pages one item at a time and collects at most maxItems
+func newCappedClient(t *testing.T, maxItems uint) mPagerDuty.IMPagerDuty {
+	layer := builder.ScheduleLayer("Weekly").Users("PUSER01").Build()
+	emulator, err := mPagerDuty.NewEmulator(&mPagerDuty.Fixtures{
+		Users: []pagerduty.User{
+			builder.User("PUSER01").Name("Alpha One").Build(),
+			builder.User("PUSER02").Name("Beta Two").Build(),
+		},
+		Schedules: []pagerduty.Schedule{
+			builder.Schedule("PSCHED1").Name("Alpha Primary").Layer(layer).Build(),
+			builder.Schedule("PSCHED2").Name("Beta Primary").Team("PTEAM01").Layer(layer).Build(),
+		},
+	})
+	assert.Nil(t, err)
+	t.Cleanup(emulator.Close)
+
+	config := mPagerDuty.DefaultConfig()
+	config.PageSize = 1
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithConfig(config),
+		mPagerDuty.WithMaxItems(maxItems))
+	assert.Nil(t, err)
+	return mPD
+}
+
+func TestPaginationLookupsAreNotCapped(t *testing.T) {
+	mPD := newCappedClient(t, 1)
+
+	// the cap limits what is collected, not what lookups and filters look at
+	id, err := mPD.GetUserIDbyName("Beta Two")
+	assert.Nil(t, err)
+	assert.Equal(t, "PUSER02", id)
+	id, _, err = mPD.GetScheduleIDbyName("Beta Primary")
+	assert.Nil(t, err)
+	assert.Equal(t, "PSCHED2", id)
+	schedules, err := mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{TeamIDs: []string{"PTEAM01"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PSCHED2"}, scheduleIDs(schedules))
+}
+
+func TestPaginationCollectsAcrossPages(t *testing.T) {
+	users, err := newCappedClient(t, 0).ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+
+	users, err = newCappedClient(t, 2).ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2, "a cap the list fits in changes nothing")
+
+	// a list that does not fit is reported rather than cut short
+	users, err = newCappedClient(t, 1).ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.True(t, errors.Is(err, mPagerDuty.ErrTruncated), "%v", err)
+	assert.Nil(t, users)
+}
@@ -0,0 +1,136 @@
+package mPagerDuty_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+var fastRetries = mPagerDuty.RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    5 * time.Millisecond,
+	Budget:      time.Second,
+}
+
+// failingServer answers the first failures requests with status and the rest with body
+func failingServer(failures int, status int, header http.Header, body interface{}) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests <= failures {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"error":{"message":"failure","code":2020}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	return server, &requests
+}
+
+func TestRetry(t *testing.T) {
+	users := pagerduty.ListUsersResponse{Users: []pagerduty.User{{Name: "Timur Kalandarov"}}}
+	incident := map[string]interface{}{"incident": pagerduty.Incident{Title: "The server is on fire"}}
+
+	tests := []struct {
+		name             string
+		failures         int
+		status           int
+		header           http.Header
+		policy           mPagerDuty.RetryPolicy
+		createIncident   bool
+		expectedRequests int
+		expectedErr      bool
+	}{
+		{"rate limited reads are retried", 2, http.StatusTooManyRequests, nil, fastRetries, false, 3, false},
+		{"server errors on reads are retried", 3, http.StatusBadGateway, nil, fastRetries, false, 4, false},
+		{"attempts are limited", 5, http.StatusServiceUnavailable, nil, fastRetries, false, 4, true},
+		{"client errors are not retried", 1, http.StatusNotFound, nil, fastRetries, false, 1, true},
+		{"retries can be disabled", 1, http.StatusTooManyRequests, nil, mPagerDuty.NoRetry, false, 1, true},
+		{"rate limit reset beyond the budget is not waited for", 1, http.StatusTooManyRequests, http.Header{"Ratelimit-Reset": {"5"}}, fastRetries, false, 1, true},
+		{"rate limited creates are retried", 1, http.StatusTooManyRequests, nil, fastRetries, true, 2, false},
+		{"server errors on creates are not retried", 1, http.StatusInternalServerError, nil, fastRetries, true, 1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body interface{} = users
+			if test.createIncident {
+				body = incident
+			}
+			server, requests := failingServer(test.failures, test.status, test.header, body)
+			defer server.Close()
+
+			mPD := liveClientForServer(t, server, mPagerDuty.WithRetryPolicy(test.policy))
+			var err error
+			if test.createIncident {
+				_, err = mPD.CreateIncident("The server is on fire", "P03NRF0", "high", "", "")
+			} else {
+				_, err = mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+			}
+
+			if test.expectedErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.expectedRequests, *requests)
+		})
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	server, requests := failingServer(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}},
+		pagerduty.ListUsersResponse{Users: []pagerduty.User{{Name: "Timur Kalandarov"}}})
+	defer server.Close()
+
+	mPD := liveClientForServer(t, server, mPagerDuty.WithRetryPolicy(mPagerDuty.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}))
+	started := time.Now()
+	_, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, *requests)
+	assert.GreaterOrEqual(t, time.Since(started), time.Second)
+}
+
+func TestRetryDelayGrowsWithoutMaxDelay(t *testing.T) {
+	server, requests := failingServer(8, http.StatusServiceUnavailable, nil,
+		pagerduty.ListUsersResponse{Users: []pagerduty.User{{Name: "Timur Kalandarov"}}})
+	defer server.Close()
+
+	var delays []time.Duration
+	hook := mPagerDuty.HookFunc(func(ctx context.Context, event mPagerDuty.Event) {
+		if event.Kind == mPagerDuty.EventRetry {
+			delays = append(delays, event.Latency)
+		}
+	})
+	baseDelay := 100 * time.Microsecond
+	mPD := liveClientForServer(t, server,
+		mPagerDuty.WithRetryPolicy(mPagerDuty.RetryPolicy{MaxAttempts: 9, BaseDelay: baseDelay}),
+		mPagerDuty.WithHooks(hook))
+	_, err := mPD.ListAllUsers(pagerduty.ListUsersOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 9, *requests)
+
+	// with full jitter, every delay stays within BaseDelay only if the backoff never grows,
+	// which with 8 retries that double it is as good as impossible
+	longest := time.Duration(0)
+	for _, delay := range delays {
+		if delay > longest {
+			longest = delay
+		}
+	}
+	assert.Len(t, delays, 8)
+	assert.Greater(t, longest, baseDelay, "%v", delays)
+}
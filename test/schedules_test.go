@@ -0,0 +1,311 @@
+package mPagerDuty_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	mPagerDuty "mpagerduty/pkg"
+	"mpagerduty/pkg/builder"
+
+	"github.com/PagerDuty/go-pagerduty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeSchedules(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	layer := builder.ScheduleLayer("Weekly").Users("PUSER01", "PUSER02", "PUSER01").Rotation(start, 7*24*time.Hour).Between(start, time.Time{})
+
+	schedule, err := mPD.CreateSchedule(builder.Schedule("").Name("Platform Secondary").Team("PTEAM01").Layer(layer.Build()).Build())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, schedule.ID)
+	assert.NotEmpty(t, schedule.ScheduleLayers[0].ID)
+	assert.Equal(t, []pagerduty.APIObject{
+		{ID: "PUSER01", Type: "user_reference", Summary: "Ada Lovelace"},
+		{ID: "PUSER02", Type: "user_reference", Summary: "Grace Hopper"},
+	}, schedule.Users, "the users of the layers are listed once")
+
+	// team filters apply on top of queries
+	schedules, err := mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{TeamIDs: []string{"PTEAM01"}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{schedule.ID}, scheduleIDs(schedules))
+	schedules, err = mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{Query: "primary", TeamIDs: []string{"PTEAM01"}})
+	assert.Nil(t, err)
+	assert.Empty(t, schedules)
+	schedules, err = mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{})
+	assert.Nil(t, err)
+	assert.Len(t, schedules, 2)
+
+	// schedules are checked like PagerDuty checks them
+	_, err = mPD.CreateSchedule(builder.Schedule("").TimeZone("Mars/Olympus_Mons").Layer(layer.Build()).Build())
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "unknown time zone: %v", err)
+	_, err = mPD.CreateSchedule(builder.Schedule("").Layer(builder.ScheduleLayer("Empty").Build()).Build())
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "layer without users: %v", err)
+	_, err = mPD.CreateSchedule(builder.Schedule("").Layer(builder.ScheduleLayer("Ghosts").Users("PNOSUCH").Build()).Build())
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "unknown user: %v", err)
+
+	// deleting a schedule deletes its overrides
+	_, err = mPD.CreateOverride(schedule.ID, "PUSER02", "2030-01-02T00:00:00Z", "2030-01-03T00:00:00Z")
+	assert.Nil(t, err)
+	assert.Nil(t, mPD.DeleteSchedule(schedule.ID))
+	_, err = mPD.GetOverrides(schedule.ID, "2030-01-01T00:00:00Z", "2030-01-08T00:00:00Z", false)
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound))
+
+	// but schedules escalation policies escalate to stay
+	err = mPD.DeleteSchedule("PSCHED1")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "schedule in use: %v", err)
+	_, err = mPD.GetScheduleByID("PSCHED1", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+}
+
+func TestFakeSchedulesCopies(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	start := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// what a read returns does not share anything with the store
+	schedule, err := mPD.GetScheduleByID("PSCHED1", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	schedule.ScheduleLayers[0].Name = "MUTATED"
+	schedule.ScheduleLayers[0].Users[0].User.ID = "MUTATED"
+	schedules, err := mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{})
+	assert.Nil(t, err)
+	schedules[0].ScheduleLayers[0].Name = "MUTATED"
+	schedule, err = mPD.GetScheduleByID("PSCHED1", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Daily", schedule.ScheduleLayers[0].Name)
+	assert.Equal(t, "PUSER01", schedule.ScheduleLayers[0].Users[0].User.ID)
+
+	// nor does the schedule passed to create, or the one it returns
+	input := builder.Schedule("").Name("Platform Secondary").Team("PTEAM01").
+		Layer(builder.ScheduleLayer("Weekly").Users("PUSER01").Rotation(start, 7*24*time.Hour).Between(start, time.Time{}).Build()).
+		Build()
+	created, err := mPD.CreateSchedule(input)
+	assert.Nil(t, err)
+	input.ScheduleLayers[0].Users[0].User.ID = "MUTATED"
+	input.Teams[0].ID = "MUTATED"
+	created.ScheduleLayers[0].Name = "MUTATED"
+	schedule, err = mPD.GetScheduleByID(created.ID, pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Weekly", schedule.ScheduleLayers[0].Name)
+	assert.Equal(t, "PUSER01", schedule.ScheduleLayers[0].Users[0].User.ID)
+	assert.Equal(t, "PTEAM01", schedule.Teams[0].ID)
+}
+
+func TestCachingClientSchedules(t *testing.T) {
+	inner := &mPagerDuty.FakePDClient{}
+	mPD := mPagerDuty.NewCachingClient(inner, mPagerDuty.DefaultCacheTTLs)
+
+	for i := 0; i < 2; i++ {
+		_, err := mPD.GetScheduleByID("PUHMCXV", pagerduty.GetScheduleOptions{})
+		assert.Nil(t, err)
+		_, err = mPD.ListSchedules(mPagerDuty.ListSchedulesOptions{Query: "caleb"})
+		assert.Nil(t, err)
+	}
+	assert.Len(t, inner.CallsTo("GetScheduleByID"), 1)
+	assert.Len(t, inner.CallsTo("ListSchedules"), 1)
+
+	// changes to schedules invalidate what was cached of them
+	layer := builder.ScheduleLayer("Weekly").Users("PJ6XOVE").Build()
+	_, err := mPD.UpdateSchedule("PUHMCXV", builder.Schedule("").Name("Caleb Young TESTING").Layer(layer).Build())
+	assert.Nil(t, err)
+	schedule, err := mPD.GetScheduleByID("PUHMCXV", pagerduty.GetScheduleOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "UTC", schedule.TimeZone)
+	assert.Len(t, inner.CallsTo("GetScheduleByID"), 2)
+}
+
+func scheduleIDs(schedules []pagerduty.Schedule) []string {
+	ids := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		ids = append(ids, schedule.ID)
+	}
+	return ids
+}
+
+func TestRenderedSchedule(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	fake, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	day := func(d, hour int) time.Time { return time.Date(2030, 1, d, hour, 0, 0, 0, time.UTC) }
+	entry := func(start, end time.Time, userID, userName string) mPagerDuty.RenderedEntry {
+		newYork, _ := time.LoadLocation("America/New_York")
+		return mPagerDuty.RenderedEntry{Start: start.In(newYork), End: end.In(newYork), UserID: userID, UserName: userName}
+	}
+
+	for name, mPD := range map[string]mPagerDuty.IMPagerDuty{"fake": fake, "emulator": newEmulatedClient(t)} {
+		// PSCHED1 hands over from Ada to Grace at midnight, and Grace overrides Ada on the first from 9 to 5
+		rendered, err := mPD.GetRenderedSchedule("PSCHED1", day(1, 0), day(3, 0), "America/New_York")
+		if !assert.Nil(t, err, name) {
+			continue
+		}
+		assert.Equal(t, "America/New_York", rendered.TimeZone, name)
+		assert.Equal(t, []mPagerDuty.RenderedEntry{
+			entry(day(1, 0), day(1, 9), "PUSER01", "Ada Lovelace"),
+			entry(day(1, 9), day(1, 17), "PUSER02", "Grace Hopper"),
+			entry(day(1, 17), day(2, 0), "PUSER01", "Ada Lovelace"),
+			entry(day(2, 0), day(3, 0), "PUSER02", "Grace Hopper"),
+		}, rendered.Final, name)
+		assert.Equal(t, []mPagerDuty.RenderedEntry{
+			entry(day(1, 9), day(1, 17), "PUSER02", "Grace Hopper"),
+		}, rendered.Overrides, name)
+		if assert.Len(t, rendered.Layers, 1, name) {
+			assert.Equal(t, "PLAYER1", rendered.Layers[0].ID, name)
+			assert.Equal(t, []mPagerDuty.RenderedEntry{
+				entry(day(1, 0), day(2, 0), "PUSER01", "Ada Lovelace"),
+				entry(day(2, 0), day(3, 0), "PUSER02", "Grace Hopper"),
+			}, rendered.Layers[0].Entries, name)
+		}
+
+		// entries are cut to the window, in the time zone of the schedule unless another one is asked for
+		rendered, err = mPD.GetRenderedSchedule("PSCHED1", day(1, 12), day(1, 18), "")
+		if assert.Nil(t, err, name) {
+			assert.Equal(t, "Europe/London", rendered.TimeZone, name)
+			if assert.Len(t, rendered.Final, 2, name) {
+				assert.True(t, rendered.Final[0].Start.Equal(day(1, 12)), name)
+				assert.Equal(t, "Europe/London", rendered.Final[0].Start.Location().String(), name)
+				assert.True(t, rendered.Final[1].End.Equal(day(1, 18)), name)
+			}
+		}
+
+		_, err = mPD.GetRenderedSchedule("PSCHED1", day(2, 0), day(1, 0), "")
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: window ending before it starts: %v", name, err)
+		_, err = mPD.GetRenderedSchedule("PSCHED1", day(1, 0), day(2, 0), "Mars/Olympus_Mons")
+		assert.True(t, errors.Is(err, mPagerDuty.ErrInvalidArgument), "%s: unknown time zone: %v", name, err)
+		_, err = mPD.GetRenderedSchedule("PNOSUCH", day(1, 0), day(2, 0), "")
+		assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "%s: unknown schedule: %v", name, err)
+	}
+}
+
+func TestRenderedScheduleLayers(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+
+	// Grace covers the working hours of every weekday on a second layer, over Ada's weekly turns
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	weekly := builder.ScheduleLayer("Weekly").Users("PUSER01").Rotation(monday, 7*24*time.Hour).Between(monday, time.Time{})
+	workingHours := builder.ScheduleLayer("Working Hours").Users("PUSER02").Rotation(monday, 7*24*time.Hour).Between(monday, time.Time{})
+	for day := time.Monday; day <= time.Friday; day++ {
+		workingHours.WeeklyRestriction(day, "09:00:00", 8*time.Hour)
+	}
+	schedule, err := mPD.CreateSchedule(builder.Schedule("").Name("Layered").Layer(weekly.Build()).Layer(workingHours.Build()).Build())
+	assert.Nil(t, err)
+
+	rendered, err := mPD.GetRenderedSchedule(schedule.ID, monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 7), "")
+	assert.Nil(t, err)
+	friday := monday.AddDate(0, 0, 4)
+	assert.Equal(t, []mPagerDuty.RenderedEntry{
+		{Start: friday, End: friday.Add(9 * time.Hour), UserID: "PUSER01", UserName: "Ada Lovelace"},
+		{Start: friday.Add(9 * time.Hour), End: friday.Add(17 * time.Hour), UserID: "PUSER02", UserName: "Grace Hopper"},
+		{Start: friday.Add(17 * time.Hour), End: monday.AddDate(0, 0, 7), UserID: "PUSER01", UserName: "Ada Lovelace"},
+	}, rendered.Final, "the weekend is Ada's")
+
+	// a daily restriction that wraps around midnight covers the small hours of the next day
+	nights := builder.ScheduleLayer("Nights").Users("PUSER02").Rotation(monday, 24*time.Hour).Between(monday, time.Time{}).DailyRestriction("22:00:00", 8*time.Hour)
+	schedule, err = mPD.CreateSchedule(builder.Schedule("").Name("Nights").Layer(nights.Build()).Build())
+	assert.Nil(t, err)
+	rendered, err = mPD.GetRenderedSchedule(schedule.ID, monday, monday.AddDate(0, 0, 1), "")
+	assert.Nil(t, err)
+	assert.Equal(t, []mPagerDuty.RenderedEntry{
+		{Start: monday, End: monday.Add(6 * time.Hour), UserID: "PUSER02", UserName: "Grace Hopper"},
+		{Start: monday.Add(22 * time.Hour), End: monday.AddDate(0, 0, 1), UserID: "PUSER02", UserName: "Grace Hopper"},
+	}, rendered.Final)
+}
+
+func TestResolveSchedule(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	mPD, err := mPagerDuty.NewFakePDClient(fixtures)
+	assert.Nil(t, err)
+	layer := builder.ScheduleLayer("Weekly").Users("PUSER01").Build()
+	for _, name := range []string{"Plateforme Sécurité", "Database Nights", "Database Days"} {
+		_, err := mPD.CreateSchedule(builder.Schedule("").Name(name).Layer(layer).Build())
+		assert.Nil(t, err)
+	}
+
+	for name, expected := range map[string]struct {
+		schedule string
+		exact    bool
+	}{
+		"platform primary":      {"Platform Primary", true},
+		"  Platform   PRIMARY ": {"Platform Primary", true},
+		"plateforme securite":   {"Plateforme Sécurité", true},
+		"Primary":               {"Platform Primary", false},
+		"Platfrom Primary":      {"Platform Primary", false},
+		"Database Night":        {"Database Nights", false},
+		"Payments":              {"", false},
+	} {
+		match, err := mPD.ResolveSchedule(name)
+		if expected.schedule == "" {
+			assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "%s: %v", name, err)
+			continue
+		}
+		if assert.Nil(t, err, name) {
+			assert.Equal(t, expected.schedule, match.Name, name)
+			assert.Equal(t, expected.exact, match.Exact, name)
+		}
+	}
+
+	// a partial name that fits several schedules is reported with all of them
+	_, err = mPD.ResolveSchedule("database")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrAmbiguous), "%v", err)
+	var ambiguous *mPagerDuty.AmbiguousNameError
+	if assert.True(t, errors.As(err, &ambiguous)) {
+		assert.Equal(t, "database", ambiguous.Name)
+		assert.ElementsMatch(t, []string{"Database Nights", "Database Days"}, scheduleMatchNames(ambiguous.Candidates))
+		assert.Contains(t, err.Error(), "Database Nights")
+	}
+
+	// so are duplicates, which GetScheduleIDbyName picks the first of
+	_, err = mPD.CreateSchedule(builder.Schedule("").Name("PLATFORM PRIMARY").Layer(layer).Build())
+	assert.Nil(t, err)
+	_, err = mPD.ResolveSchedule("Platform Primary")
+	if assert.True(t, errors.As(err, &ambiguous), "%v", err) {
+		assert.Equal(t, []string{"Platform Primary", "PLATFORM PRIMARY"}, scheduleMatchNames(ambiguous.Candidates))
+	}
+}
+
+func TestResolveSchedulePrefix(t *testing.T) {
+	fixtures, err := mPagerDuty.LoadFixtures("testdata/fixtures.yaml")
+	assert.Nil(t, err)
+	emulator, err := mPagerDuty.NewEmulator(fixtures)
+	assert.Nil(t, err)
+	defer emulator.Close()
+
+	config := mPagerDuty.DefaultConfig()
+	config.SchedulePrefix = "Platform"
+	mPD, err := mPagerDuty.GetMPagerDutyClient(authtoken,
+		mPagerDuty.WithMode(mPagerDuty.ModeEmulator),
+		mPagerDuty.WithAPIEndpoint(emulator.URL),
+		mPagerDuty.WithConfig(config))
+	assert.Nil(t, err)
+
+	// the prefix may be left out or included
+	for _, name := range []string{"Primary", "platform primary", "Primray"} {
+		match, err := mPD.ResolveSchedule(name)
+		if assert.Nil(t, err, name) {
+			assert.Equal(t, "PSCHED1", match.ID, name)
+			assert.Equal(t, "Europe/London", match.TimeZone, name)
+		}
+	}
+	_, err = mPD.ResolveSchedule("Tertiary")
+	assert.True(t, errors.Is(err, mPagerDuty.ErrNotFound), "%v", err)
+}
+
+func scheduleMatchNames(matches []mPagerDuty.ScheduleMatch) []string {
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Name)
+	}
+	return names
+}